@@ -14,21 +14,7 @@ import (
 
 	"github.com/dunooo0ooo/avito-test-task/internal/app"
 	"github.com/dunooo0ooo/avito-test-task/pkg/config"
-
-	userapp "github.com/dunooo0ooo/avito-test-task/internal/user/application"
-	userhttp "github.com/dunooo0ooo/avito-test-task/internal/user/delivery/http"
-	userpg "github.com/dunooo0ooo/avito-test-task/internal/user/infra/postgres"
-
-	prapp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/application"
-	prhttp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
-	prpg "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/infra/postgres"
-
-	teamapp "github.com/dunooo0ooo/avito-test-task/internal/team/application"
-	teamhttp "github.com/dunooo0ooo/avito-test-task/internal/team/delivery/http"
-	teampg "github.com/dunooo0ooo/avito-test-task/internal/team/infra/postgres"
-
-	stats "github.com/dunooo0ooo/avito-test-task/internal/stats/application"
-	statshttp "github.com/dunooo0ooo/avito-test-task/internal/stats/delivery/http"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
 )
 
 func main() {
@@ -47,54 +33,47 @@ func main() {
 
 	log.Info("config loaded",
 		zap.String("http_addr", cfg.HTTP.Addr),
+		zap.String("storage", cfg.Storage.Driver),
 	)
 
-	poolCfg, err := pgxpool.ParseConfig(cfg.Postgres.DSN())
-	if err != nil {
-		log.Fatal("cannot parse postgres DSN", zap.Error(err))
+	var storage app.Storage
+
+	switch cfg.Storage.Driver {
+	case config.StorageMemory:
+		storage = app.NewMemoryStorage(memstore.New())
+
+		log.Info("using in-memory storage")
+	case config.StoragePostgres:
+		var poolCfg *pgxpool.Config
+		poolCfg, err = pgxpool.ParseConfig(cfg.Postgres.DSN())
+		if err != nil {
+			log.Fatal("cannot parse postgres DSN", zap.Error(err))
+		}
+		poolCfg.MaxConns = cfg.Postgres.MaxConns
+		poolCfg.MinConns = cfg.Postgres.MinConns
+
+		var dbpool *pgxpool.Pool
+		dbpool, err = pgxpool.NewWithConfig(ctx, poolCfg)
+		if err != nil {
+			log.Fatal("cannot connect to postgres", zap.Error(err))
+		}
+		defer dbpool.Close()
+
+		log.Info("connected to postgres",
+			zap.String("host", cfg.Postgres.Host),
+			zap.Int("port", cfg.Postgres.Port),
+			zap.String("db", cfg.Postgres.DBName),
+		)
+
+		storage = app.NewPostgresStorage(dbpool)
+	default:
+		log.Fatal("unknown storage driver", zap.String("storage", cfg.Storage.Driver))
 	}
-	poolCfg.MaxConns = cfg.Postgres.MaxConns
-	poolCfg.MinConns = cfg.Postgres.MinConns
 
-	dbpool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	mux, err := app.NewRouter(storage, cfg.Reviewers, log)
 	if err != nil {
-		log.Fatal("cannot connect to postgres", zap.Error(err))
+		log.Fatal("failed to build router", zap.Error(err))
 	}
-	defer dbpool.Close()
-
-	log.Info("connected to postgres",
-		zap.String("host", cfg.Postgres.Host),
-		zap.Int("port", cfg.Postgres.Port),
-		zap.String("db", cfg.Postgres.DBName),
-	)
-
-	userRepo := userpg.NewUserRepository(dbpool)
-	prRepo := prpg.NewPullRequestRepository(dbpool)
-	teamRepo := teampg.NewTeamRepository(dbpool)
-
-	userSvc := userapp.NewUserService(userRepo, prRepo, log)
-	prSvc := prapp.NewPullRequestService(prRepo, userRepo, log)
-	teamSvc := teamapp.NewTeamService(teamRepo, userRepo, log)
-	statsSvc := stats.NewStatsService(prRepo, log)
-
-	mux := http.NewServeMux()
-
-	userHandler := userhttp.NewUserHandler(userSvc)
-	userHandler.RegisterRoutes(mux)
-
-	prHandler := prhttp.NewPullRequestHandler(prSvc)
-	prHandler.RegisterRoutes(mux)
-
-	teamHandler := teamhttp.NewTeamHandler(teamSvc)
-	teamHandler.RegisterRoutes(mux)
-
-	statsHandler := statshttp.NewStatsHandler(statsSvc)
-	statsHandler.RegisterRoutes(mux)
-
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status":"ok"}`))
-	})
 
 	srv := &http.Server{
 		Addr:         cfg.HTTP.Addr,
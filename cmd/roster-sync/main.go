@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/app"
+	"github.com/dunooo0ooo/avito-test-task/internal/roster/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/config"
+	"github.com/dunooo0ooo/avito-test-task/pkg/logger"
+)
+
+// roster-sync compares a roster file with the database and prints the plan;
+// with -apply the plan is also written.
+func main() {
+	file := flag.String("file", "", "path to the roster file (YAML or JSON)")
+	apply := flag.Bool("apply", false, "apply the plan instead of only printing it")
+	flag.Parse()
+
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "usage: roster-sync -file roster.yaml [-apply]")
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.Load()
+
+	log, err := logger.New(cfg.Logger.Level)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer func(log *zap.Logger) {
+		_ = log.Sync()
+	}(log)
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		log.Fatal("cannot read roster", zap.String("file", *file), zap.Error(err))
+	}
+
+	roster, err := domain.Parse(data)
+	if err != nil {
+		log.Fatal("cannot parse roster", zap.String("file", *file), zap.Error(err))
+	}
+
+	dbpool, err := pgxpool.New(ctx, cfg.Postgres.DSN())
+	if err != nil {
+		log.Fatal("cannot connect to postgres", zap.Error(err))
+	}
+	defer dbpool.Close()
+
+	svc, err := app.NewRosterSync(app.NewPostgresStorage(dbpool), cfg.Reviewers, log)
+	if err != nil {
+		log.Fatal("failed to build roster sync", zap.Error(err))
+	}
+
+	res, err := svc.Sync(ctx, *roster, !*apply)
+	if err != nil {
+		log.Fatal("roster sync failed", zap.Error(err))
+	}
+
+	printResult(os.Stdout, res)
+}
+
+func printResult(w io.Writer, res *domain.SyncResult) {
+	p := res.Plan
+
+	if p.Empty() {
+		fmt.Fprintln(w, "roster is in sync, nothing to do")
+		return
+	}
+
+	for _, name := range p.CreateTeams {
+		fmt.Fprintf(w, "+ team %s\n", name)
+	}
+	for _, u := range p.CreateUsers {
+		fmt.Fprintf(w, "+ user %s (%s) in %s, active=%t\n", u.UserID, u.Username, u.TeamName, u.IsActive)
+	}
+	for _, m := range p.MoveUsers {
+		fmt.Fprintf(w, "~ user %s: %s -> %s\n", m.UserID, m.FromTeam, m.ToTeam)
+	}
+	for _, c := range p.RenameUsers {
+		fmt.Fprintf(w, "~ user %s: username %q -> %q\n", c.UserID, c.From, c.To)
+	}
+	for _, id := range p.ActivateUsers {
+		fmt.Fprintf(w, "~ user %s: activate\n", id)
+	}
+	for _, d := range p.DeactivateUsers {
+		fmt.Fprintf(w, "- team %s: deactivate %s\n", d.TeamName, strings.Join(d.UserIDs, ", "))
+	}
+
+	if res.DryRun {
+		fmt.Fprintln(w, "plan only, run with -apply to write it")
+		return
+	}
+
+	fmt.Fprintf(w, "applied, %d pull requests got new reviewers\n", len(res.PullRequests))
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/dunooo0ooo/avito-test-task/pkg/config"
+
+	userapp "github.com/dunooo0ooo/avito-test-task/internal/user/application"
+	userhttp "github.com/dunooo0ooo/avito-test-task/internal/user/delivery/http"
+
+	prapp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/application"
+	prhttp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
+
+	teamapp "github.com/dunooo0ooo/avito-test-task/internal/team/application"
+	teamhttp "github.com/dunooo0ooo/avito-test-task/internal/team/delivery/http"
+
+	stats "github.com/dunooo0ooo/avito-test-task/internal/stats/application"
+	statshttp "github.com/dunooo0ooo/avito-test-task/internal/stats/delivery/http"
+
+	rosterapp "github.com/dunooo0ooo/avito-test-task/internal/roster/application"
+	rosterhttp "github.com/dunooo0ooo/avito-test-task/internal/roster/delivery/http"
+)
+
+func newReviewerSelector(storage Storage, cfg config.ReviewersConfig, log *zap.Logger) (prapp.ReviewerSelector, error) {
+	defaultSelector, err := prapp.NewReviewerSelector(cfg.Strategy, storage.PullRequests, log)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSelectors := make(map[string]prapp.ReviewerSelector, len(cfg.TeamStrategies))
+	for team, strategy := range cfg.TeamStrategies {
+		sel, selErr := prapp.NewReviewerSelector(strategy, storage.PullRequests, log)
+		if selErr != nil {
+			return nil, selErr
+		}
+		teamSelectors[team] = sel
+	}
+
+	return prapp.NewTeamSelector(defaultSelector, teamSelectors), nil
+}
+
+// NewRosterSync builds the roster sync service on its own, for tools that
+// apply a roster without serving HTTP.
+func NewRosterSync(storage Storage, cfg config.ReviewersConfig, log *zap.Logger) (*rosterapp.SyncService, error) {
+	selector, err := newReviewerSelector(storage, cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	userSvc := userapp.NewUserService(storage.Users, storage.PullRequests, storage.Tx, selector, log)
+
+	return rosterapp.NewSyncService(storage.Teams, storage.Users, userSvc, storage.Tx, log), nil
+}
+
+func NewRouter(storage Storage, cfg config.ReviewersConfig, log *zap.Logger) (*http.ServeMux, error) {
+	selector, err := newReviewerSelector(storage, cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("reviewer selection configured",
+		zap.String("strategy", cfg.Strategy),
+		zap.Any("team_strategies", cfg.TeamStrategies),
+		zap.Int("required_approvals", cfg.RequiredApprovals),
+		zap.Any("team_required_approvals", cfg.TeamRequiredApprovals),
+	)
+
+	policy := prapp.MergePolicy{
+		RequiredApprovals:     cfg.RequiredApprovals,
+		TeamRequiredApprovals: cfg.TeamRequiredApprovals,
+	}
+
+	userSvc := userapp.NewUserService(storage.Users, storage.PullRequests, storage.Tx, selector, log)
+	prSvc := prapp.NewPullRequestService(storage.PullRequests, storage.Users, storage.Teams, storage.Tx, selector, policy, log)
+	teamSvc := teamapp.NewTeamService(storage.Teams, storage.Users, storage.Tx, log)
+	statsSvc := stats.NewStatsService(storage.PullRequests, log)
+	rosterSvc := rosterapp.NewSyncService(storage.Teams, storage.Users, userSvc, storage.Tx, log)
+
+	mux := http.NewServeMux()
+
+	userHandler := userhttp.NewUserHandler(userSvc)
+	userHandler.RegisterRoutes(mux)
+
+	prHandler := prhttp.NewPullRequestHandler(prSvc)
+	prHandler.RegisterRoutes(mux)
+
+	teamHandler := teamhttp.NewTeamHandler(teamSvc)
+	teamHandler.RegisterRoutes(mux)
+
+	statsHandler := statshttp.NewStatsHandler(statsSvc)
+	statsHandler.RegisterRoutes(mux)
+
+	rosterHandler := rosterhttp.NewRosterHandler(rosterSvc)
+	rosterHandler.RegisterRoutes(mux)
+
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	return mux, nil
+}
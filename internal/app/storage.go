@@ -0,0 +1,43 @@
+package app
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
+
+	prmem "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/infra/memory"
+	prpg "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/infra/postgres"
+	teammem "github.com/dunooo0ooo/avito-test-task/internal/team/infra/memory"
+	teampg "github.com/dunooo0ooo/avito-test-task/internal/team/infra/postgres"
+	usermem "github.com/dunooo0ooo/avito-test-task/internal/user/infra/memory"
+	userpg "github.com/dunooo0ooo/avito-test-task/internal/user/infra/postgres"
+)
+
+type Storage struct {
+	Users        userdomain.UserRepository
+	PullRequests prdomain.PullRequestRepository
+	Teams        teamdomain.TeamRepository
+	Tx           txmanager.TxManager
+}
+
+func NewPostgresStorage(pool *pgxpool.Pool) Storage {
+	return Storage{
+		Users:        userpg.NewUserRepository(pool),
+		PullRequests: prpg.NewPullRequestRepository(pool),
+		Teams:        teampg.NewTeamRepository(pool),
+		Tx:           txmanager.NewPostgres(pool),
+	}
+}
+
+func NewMemoryStorage(store *memstore.Store) Storage {
+	return Storage{
+		Users:        usermem.NewUserRepository(store),
+		PullRequests: prmem.NewPullRequestRepository(store),
+		Teams:        teammem.NewTeamRepository(store),
+		Tx:           store,
+	}
+}
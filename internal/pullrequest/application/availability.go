@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"go.uber.org/zap"
+)
+
+// ReviewerAvailability decides which review candidates can take another pull
+// request right now: those who are not on leave and have not reached their
+// review limit. It is shared by reviewer assignment and by reassignment when
+// users leave, so both apply the same rules.
+type ReviewerAvailability struct {
+	users  userdomain.UserRepository
+	prs    prdomain.PullRequestRepository
+	logger *zap.Logger
+}
+
+func NewReviewerAvailability(
+	users userdomain.UserRepository,
+	prs prdomain.PullRequestRepository,
+	logger *zap.Logger,
+) *ReviewerAvailability {
+	return &ReviewerAvailability{users: users, prs: prs, logger: logger}
+}
+
+// Availability is what Check found out about a set of candidates. Open reviews
+// are only counted for candidates that have a limit.
+type Availability struct {
+	absent map[string]struct{}
+	limits userdomain.ReviewLimits
+	load   map[string]int64
+}
+
+// Check loads the absences, review limits and open review counts of the
+// candidates.
+func (a *ReviewerAvailability) Check(ctx context.Context, candidates []string) (*Availability, error) {
+	res := &Availability{
+		absent: make(map[string]struct{}),
+		load:   make(map[string]int64),
+	}
+	if len(candidates) == 0 {
+		return res, nil
+	}
+
+	absent, err := a.users.ListAbsent(ctx, candidates, time.Now().UTC())
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Error("failed to check reviewer absences",
+				zap.Strings("candidates", candidates),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	for _, id := range absent {
+		res.absent[id] = struct{}{}
+	}
+
+	res.limits, err = a.users.ListReviewLimits(ctx, candidates)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Error("failed to load reviewer limits",
+				zap.Strings("candidates", candidates),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	if len(res.limits) == 0 {
+		return res, nil
+	}
+
+	limited := make([]string, 0, len(res.limits))
+	for _, id := range candidates {
+		if _, ok := res.limits[id]; ok {
+			limited = append(limited, id)
+		}
+	}
+
+	load, err := a.prs.CountOpenByReviewers(ctx, limited)
+	if err != nil {
+		if a.logger != nil {
+			a.logger.Error("failed to count open reviews for limited reviewers",
+				zap.Strings("reviewers", limited),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	for id, n := range load {
+		res.load[id] = n
+	}
+
+	return res, nil
+}
+
+// Free reports whether userID can take another review.
+func (a *Availability) Free(userID string) bool {
+	return !a.isAbsent(userID) && !a.limits.Reached(userID, a.load[userID])
+}
+
+// Split drops the absent candidates and separates those at their review limit,
+// so that callers can tell an overloaded team from an empty one. The order of
+// candidates is kept.
+func (a *Availability) Split(candidates []string) (free, full []string) {
+	free = make([]string, 0, len(candidates))
+	for _, id := range candidates {
+		switch {
+		case a.isAbsent(id):
+		case a.limits.Reached(id, a.load[id]):
+			full = append(full, id)
+		default:
+			free = append(free, id)
+		}
+	}
+	return free, full
+}
+
+// Assign counts one more open review for userID, for callers that hand out
+// several reviews before writing them.
+func (a *Availability) Assign(userID string) {
+	a.load[userID]++
+}
+
+func (a *Availability) isAbsent(userID string) bool {
+	_, ok := a.absent[userID]
+	return ok
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	prmocks "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/mocks"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	usermocks "github.com/dunooo0ooo/avito-test-task/internal/user/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReviewerAvailability_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	a := NewReviewerAvailability(userRepo, prRepo, zap.NewNop())
+	candidates := []string{"u1", "u2", "u3", "u4"}
+
+	userRepo.EXPECT().ListAbsent(gomock.Any(), candidates, gomock.Any()).Return([]string{"u1"}, nil)
+	userRepo.EXPECT().
+		ListReviewLimits(gomock.Any(), candidates).
+		Return(userdomain.ReviewLimits{"u2": 2, "u3": 2}, nil)
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), []string{"u2", "u3"}).
+		Return(map[string]int64{"u2": 2, "u3": 1}, nil)
+
+	avail, err := a.Check(context.Background(), candidates)
+	require.NoError(t, err)
+
+	free, full := avail.Split(candidates)
+	assert.Equal(t, []string{"u3", "u4"}, free)
+	assert.Equal(t, []string{"u2"}, full)
+
+	assert.False(t, avail.Free("u1"))
+	assert.True(t, avail.Free("u3"))
+	avail.Assign("u3")
+	assert.False(t, avail.Free("u3"))
+}
+
+func TestReviewerAvailability_Check_NoCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := NewReviewerAvailability(usermocks.NewMockUserRepository(ctrl), prmocks.NewMockPullRequestRepository(ctrl), nil)
+
+	avail, err := a.Check(context.Background(), nil)
+	require.NoError(t, err)
+
+	free, full := avail.Split(nil)
+	assert.Empty(t, free)
+	assert.Empty(t, full)
+}
@@ -0,0 +1,27 @@
+package application
+
+// MergePolicy sets how many APPROVED reviews a pull request needs before it can be
+// merged, looked up by the author's team. Zero means no approvals are required.
+type MergePolicy struct {
+	RequiredApprovals     int
+	TeamRequiredApprovals map[string]int
+}
+
+func (p MergePolicy) Required(teamName string) int {
+	if n, ok := p.TeamRequiredApprovals[teamName]; ok {
+		return n
+	}
+	return p.RequiredApprovals
+}
+
+func (p MergePolicy) enabled() bool {
+	if p.RequiredApprovals > 0 {
+		return true
+	}
+	for _, n := range p.TeamRequiredApprovals {
+		if n > 0 {
+			return true
+		}
+	}
+	return false
+}
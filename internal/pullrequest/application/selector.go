@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+)
+
+// ReviewerSelector chooses up to n reviewers out of candidates of the given team.
+type ReviewerSelector interface {
+	Select(ctx context.Context, teamName string, candidates []string, n int) ([]string, error)
+}
+
+func NewReviewerSelector(
+	strategy string,
+	prs prdomain.PullRequestRepository,
+	logger *zap.Logger,
+) (ReviewerSelector, error) {
+	switch strategy {
+	case "", StrategyRandom:
+		return NewRandomSelector(), nil
+	case StrategyRoundRobin:
+		return NewRoundRobinSelector(), nil
+	case StrategyLeastLoaded:
+		return NewLeastLoadedSelector(prs, logger), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", prdomain.ErrUnknownReviewerStrategy, strategy)
+	}
+}
+
+type dryRunKey struct{}
+
+// WithDryRun marks ctx as a preview. Selectors still pick reviewers but must not
+// remember the picks, so a preview does not change the outcome of the real run.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether ctx was marked by WithDryRun.
+func IsDryRun(ctx context.Context) bool {
+	dry, _ := ctx.Value(dryRunKey{}).(bool)
+	return dry
+}
+
+type RandomSelector struct{}
+
+func NewRandomSelector() *RandomSelector {
+	return &RandomSelector{}
+}
+
+func (s *RandomSelector) Select(_ context.Context, _ string, candidates []string, n int) ([]string, error) {
+	return pickRandom(candidates, n), nil
+}
+
+// RoundRobinSelector walks over the team's candidates sorted by ID,
+// continuing after the last reviewer it handed out for that team.
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		last: make(map[string]string),
+	}
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, teamName string, candidates []string, n int) ([]string, error) {
+	if n <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	sorted := make([]string, len(candidates))
+	copy(sorted, candidates)
+	sort.Strings(sorted)
+
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := sort.SearchStrings(sorted, s.last[teamName])
+	if start < len(sorted) && sorted[start] == s.last[teamName] {
+		start++
+	}
+
+	res := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		res = append(res, sorted[(start+i)%len(sorted)])
+	}
+
+	if !IsDryRun(ctx) {
+		s.last[teamName] = res[len(res)-1]
+	}
+
+	return res, nil
+}
+
+// LeastLoadedSelector prefers candidates with the fewest OPEN review assignments,
+// breaking ties randomly.
+type LeastLoadedSelector struct {
+	prs    prdomain.PullRequestRepository
+	logger *zap.Logger
+}
+
+func NewLeastLoadedSelector(prs prdomain.PullRequestRepository, logger *zap.Logger) *LeastLoadedSelector {
+	return &LeastLoadedSelector{
+		prs:    prs,
+		logger: logger,
+	}
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, teamName string, candidates []string, n int) ([]string, error) {
+	if n <= 0 || len(candidates) == 0 {
+		return nil, nil
+	}
+
+	load, err := s.prs.CountOpenByReviewers(ctx, candidates)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to count open reviews for candidates",
+				zap.String("team_name", teamName),
+				zap.Strings("candidates", candidates),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	sorted := shuffle(candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return load[sorted[i]] < load[sorted[j]]
+	})
+
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	picked := sorted[:n]
+
+	if s.logger != nil {
+		candidateLoad := make(map[string]int64, len(candidates))
+		for _, id := range candidates {
+			candidateLoad[id] = load[id]
+		}
+		s.logger.Info("reviewers selected by open review load",
+			zap.String("team_name", teamName),
+			zap.Strings("picked", picked),
+			zap.Any("open_reviews", candidateLoad),
+		)
+	}
+
+	return picked, nil
+}
+
+// TeamSelector dispatches to a team-specific selector, falling back to the default one.
+type TeamSelector struct {
+	def    ReviewerSelector
+	byTeam map[string]ReviewerSelector
+}
+
+func NewTeamSelector(def ReviewerSelector, byTeam map[string]ReviewerSelector) *TeamSelector {
+	return &TeamSelector{
+		def:    def,
+		byTeam: byTeam,
+	}
+}
+
+func (s *TeamSelector) Select(ctx context.Context, teamName string, candidates []string, n int) ([]string, error) {
+	if sel, ok := s.byTeam[teamName]; ok {
+		return sel.Select(ctx, teamName, candidates, n)
+	}
+	return s.def.Select(ctx, teamName, candidates, n)
+}
+
+func shuffle(src []string) []string {
+	out := make([]string, len(src))
+	copy(out, src)
+
+	for i := len(out) - 1; i > 0; i-- {
+		idx, _ := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		j := int(idx.Int64())
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out
+}
+
+func pickRandom(src []string, n int) []string {
+	if n <= 0 || len(src) == 0 {
+		return nil
+	}
+	if len(src) <= n {
+		out := make([]string, len(src))
+		copy(out, src)
+		return out
+	}
+
+	used := make(map[int]struct{})
+	res := make([]string, 0, n)
+
+	for len(res) < n {
+		idx, _ := rand.Int(rand.Reader, big.NewInt(int64(len(src))))
+		i := int(idx.Int64())
+		if _, ok := used[i]; ok {
+			continue
+		}
+		used[i] = struct{}{}
+		res = append(res, src[i])
+	}
+
+	return res
+}
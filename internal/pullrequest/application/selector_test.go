@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	prmocks "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewReviewerSelector(t *testing.T) {
+	sel, err := NewReviewerSelector("", nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &RandomSelector{}, sel)
+
+	sel, err = NewReviewerSelector(StrategyRoundRobin, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &RoundRobinSelector{}, sel)
+
+	sel, err = NewReviewerSelector(StrategyLeastLoaded, nil, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &LeastLoadedSelector{}, sel)
+
+	_, err = NewReviewerSelector("fancy", nil, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrUnknownReviewerStrategy))
+}
+
+func TestRandomSelector_Select(t *testing.T) {
+	sel := NewRandomSelector()
+	ctx := context.Background()
+
+	res, err := sel.Select(ctx, "backend", []string{"u1", "u2", "u3"}, 2)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.NotEqual(t, res[0], res[1])
+	assert.Subset(t, []string{"u1", "u2", "u3"}, res)
+
+	res, err = sel.Select(ctx, "backend", []string{"u1"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, res)
+}
+
+func TestRoundRobinSelector_Select(t *testing.T) {
+	sel := NewRoundRobinSelector()
+	ctx := context.Background()
+	candidates := []string{"u3", "u1", "u2"}
+
+	res, err := sel.Select(ctx, "backend", candidates, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, res)
+
+	res, err = sel.Select(ctx, "backend", candidates, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u1"}, res)
+
+	res, err = sel.Select(ctx, "frontend", candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, res)
+
+	res, err = sel.Select(ctx, "backend", []string{"u2", "u4"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, res)
+}
+
+func TestRoundRobinSelector_DryRunKeepsPosition(t *testing.T) {
+	sel := NewRoundRobinSelector()
+	candidates := []string{"u1", "u2", "u3"}
+
+	res, err := sel.Select(WithDryRun(context.Background()), "backend", candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, res)
+
+	res, err = sel.Select(context.Background(), "backend", candidates, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, res)
+}
+
+func TestLeastLoadedSelector_Select(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	sel := NewLeastLoadedSelector(prRepo, zap.NewNop())
+	candidates := []string{"u1", "u2", "u3", "u4"}
+
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), candidates).
+		Return(map[string]int64{"u1": 5, "u2": 1, "u3": 3}, nil)
+
+	res, err := sel.Select(context.Background(), "backend", candidates, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4", "u2"}, res)
+}
+
+func TestLeastLoadedSelector_TieBreak(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	sel := NewLeastLoadedSelector(prRepo, zap.NewNop())
+	candidates := []string{"u1", "u2", "u3"}
+
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), candidates).
+		Return(map[string]int64{"u3": 2}, nil).
+		AnyTimes()
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		res, err := sel.Select(context.Background(), "backend", candidates, 1)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.NotEqual(t, "u3", res[0])
+		seen[res[0]] = struct{}{}
+	}
+
+	assert.Len(t, seen, 2)
+}
+
+func TestLeastLoadedSelector_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	sel := NewLeastLoadedSelector(prRepo, zap.NewNop())
+
+	expectedErr := errors.New("db error")
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), []string{"u1"}).
+		Return(nil, expectedErr)
+
+	res, err := sel.Select(context.Background(), "backend", []string{"u1"}, 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, expectedErr))
+	assert.Nil(t, res)
+}
+
+func TestTeamSelector_Select(t *testing.T) {
+	rr := NewRoundRobinSelector()
+	sel := NewTeamSelector(NewRandomSelector(), map[string]ReviewerSelector{
+		"backend": rr,
+	})
+	ctx := context.Background()
+
+	res, err := sel.Select(ctx, "backend", []string{"u2", "u1"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, res)
+	assert.Equal(t, "u1", rr.last["backend"])
+
+	res, err = sel.Select(ctx, "frontend", []string{"u5"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u5"}, res)
+	assert.NotContains(t, rr.last, "frontend")
+}
@@ -2,38 +2,90 @@ package application
 
 import (
 	"context"
-	"crypto/rand"
-	"math/big"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
 	"go.uber.org/zap"
 )
 
 type PullRequestService struct {
-	prs    prdomain.PullRequestRepository
-	users  userdomain.UserRepository
-	logger *zap.Logger
+	prs          prdomain.PullRequestRepository
+	users        userdomain.UserRepository
+	teams        teamdomain.TeamRepository
+	tx           txmanager.TxManager
+	selector     ReviewerSelector
+	availability *ReviewerAvailability
+	policy       MergePolicy
+	logger       *zap.Logger
 }
 
 func NewPullRequestService(
 	prs prdomain.PullRequestRepository,
 	users userdomain.UserRepository,
+	teams teamdomain.TeamRepository,
+	tx txmanager.TxManager,
+	selector ReviewerSelector,
+	policy MergePolicy,
 	logger *zap.Logger,
 ) *PullRequestService {
+	if tx == nil {
+		tx = txmanager.Nop{}
+	}
+	if selector == nil {
+		selector = NewRandomSelector()
+	}
+
 	return &PullRequestService{
-		prs:    prs,
-		users:  users,
-		logger: logger,
+		prs:          prs,
+		users:        users,
+		teams:        teams,
+		tx:           tx,
+		selector:     selector,
+		availability: NewReviewerAvailability(users, prs, logger),
+		policy:       policy,
+		logger:       logger,
 	}
 }
 
+// CreatePullRequest creates an OPEN pull request with reviewers assigned, or a DRAFT
+// without reviewers when draft is set. Owners of the changed files are preferred as
+// reviewers; the files are kept so that a draft gets the same owners once ready.
 func (s *PullRequestService) CreatePullRequest(
 	ctx context.Context,
 	id string,
 	name string,
 	authorID string,
+	draft bool,
+	changedFiles []string,
+) (*prdomain.PullRequest, error) {
+	var created *prdomain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.createPullRequest(ctx, id, name, authorID, draft, changedFiles)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *PullRequestService) createPullRequest(
+	ctx context.Context,
+	id string,
+	name string,
+	authorID string,
+	draft bool,
+	changedFiles []string,
 ) (*prdomain.PullRequest, error) {
 	author, err := s.users.GetByID(ctx, authorID)
 	if err != nil {
@@ -46,8 +98,147 @@ func (s *PullRequestService) CreatePullRequest(
 		return nil, err
 	}
 
+	status := prdomain.PRStatusOpen
+	assignment := &reviewerAssignment{}
+
+	if draft {
+		status = prdomain.PRStatusDraft
+	} else {
+		assignment, err = s.selectInitialReviewers(ctx, author, changedFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reviewers := assignment.reviewers
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:   id,
+		PullRequestName: name,
+		AuthorID:        authorID,
+		Status:          status,
+		ChangedFiles:    changedFiles,
+	}
+	if err := pr.AssignReviewers(reviewers); err != nil {
+		return nil, err
+	}
+
+	if err := s.prs.Create(ctx, pr); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to create pull request",
+				zap.String("pr_id", id),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if !draft {
+		if err := s.prs.SetReviewers(ctx, id, pr.AssignedReviewers); err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to set reviewers after PR creation",
+					zap.String("pr_id", id),
+					zap.Any("reviewers", reviewers),
+					zap.Error(err),
+				)
+			}
+			return nil, err
+		}
+	}
+
+	created, err := s.prs.GetByID(ctx, id)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to reload PR after creation",
+				zap.String("pr_id", id),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+	assignment.apply(created)
+
+	if s.logger != nil {
+		s.logger.Info("pull request created",
+			zap.String("pr_id", id),
+			zap.String("author_id", authorID),
+			zap.String("status", string(status)),
+			zap.Strings("reviewers", reviewers),
+			zap.Strings("fallback_reviewers", assignment.fallback),
+			zap.Any("matched_rules", assignment.rules),
+		)
+	}
+
+	return created, nil
+}
+
+// reviewerAssignment is the outcome of picking reviewers for a pull request.
+type reviewerAssignment struct {
+	reviewers  []string
+	fallback   []string
+	rules      map[string]string
+	atCapacity []string
+}
+
+func (a *reviewerAssignment) apply(pr *prdomain.PullRequest) {
+	pr.FallbackReviewers = a.fallback
+	pr.MatchedRules = a.rules
+	pr.SkippedAtCapacity = a.atCapacity
+}
+
+func (a *reviewerAssignment) skip(full []string) {
+	for _, id := range full {
+		if !slices.Contains(a.atCapacity, id) {
+			a.atCapacity = append(a.atCapacity, id)
+		}
+	}
+}
+
+// selectInitialReviewers picks up to MaxReviewers active reviewers. Owners of the
+// changed files come first, then members of the author's team; when the team
+// settings allow it, the remaining slots are filled from fallback teams.
+func (s *PullRequestService) selectInitialReviewers(
+	ctx context.Context,
+	author *userdomain.User,
+	changedFiles []string,
+) (*reviewerAssignment, error) {
 	teamName := author.TeamName
 
+	settings, err := s.teamSettings(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &reviewerAssignment{}
+
+	owners, rules, err := s.codeOwnerCandidates(ctx, author, changedFiles)
+	if err != nil {
+		return nil, err
+	}
+	owners, full, err := s.available(ctx, owners)
+	if err != nil {
+		return nil, err
+	}
+	a.skip(full)
+
+	if len(owners) > 0 {
+		picked, err := s.selector.Select(ctx, teamName, owners, settings.MaxReviewers)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to select code owners for PR creation",
+					zap.String("team_name", teamName),
+					zap.Error(err),
+				)
+			}
+			return nil, err
+		}
+
+		a.rules = make(map[string]string, len(picked))
+		for _, id := range picked {
+			a.rules[id] = rules[id]
+		}
+		a.reviewers = append(a.reviewers, picked...)
+	}
+
 	teamMembers, err := s.users.ListByTeam(ctx, teamName)
 	if err != nil {
 		if s.logger != nil {
@@ -64,70 +255,516 @@ func (s *PullRequestService) CreatePullRequest(
 		if !u.IsActive {
 			continue
 		}
-		if u.UserID == authorID {
+		if u.UserID == author.UserID || slices.Contains(a.reviewers, u.UserID) {
 			continue
 		}
-		candidates = append(candidates, u.UserID)
+		candidates = append(candidates, u.UserID)
+	}
+
+	candidates, full, err = s.available(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	a.skip(full)
+
+	if len(a.reviewers) < settings.MaxReviewers {
+		picked, err := s.selector.Select(ctx, teamName, candidates, settings.MaxReviewers-len(a.reviewers))
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to select reviewers for PR creation",
+					zap.String("team_name", teamName),
+					zap.Error(err),
+				)
+			}
+			return nil, err
+		}
+		a.reviewers = append(a.reviewers, picked...)
+	}
+
+	if len(a.reviewers) < settings.MaxReviewers && !settings.SelfTeamOnly {
+		exclude := append([]string{author.UserID}, a.reviewers...)
+		a.fallback, full, err = s.selectFallback(ctx, teamName, settings.FallbackTeams, exclude, settings.MaxReviewers-len(a.reviewers))
+		if err != nil {
+			return nil, err
+		}
+		a.skip(full)
+		a.reviewers = append(a.reviewers, a.fallback...)
+	}
+
+	if len(a.reviewers) < settings.MinReviewers {
+		if s.logger != nil {
+			s.logger.Warn("not enough reviewer candidates",
+				zap.String("team_name", teamName),
+				zap.Int("found", len(a.reviewers)),
+				zap.Int("min_reviewers", settings.MinReviewers),
+				zap.Strings("at_capacity", a.atCapacity),
+			)
+		}
+		if len(a.atCapacity) > 0 {
+			return nil, fmt.Errorf("%w: found %d of %d, at capacity: %v",
+				prdomain.ErrReviewersAtCapacity, len(a.reviewers), settings.MinReviewers, a.atCapacity)
+		}
+		return nil, fmt.Errorf("%w: found %d of %d", prdomain.ErrNotEnoughReviewers, len(a.reviewers), settings.MinReviewers)
+	}
+
+	return a, nil
+}
+
+// codeOwnerCandidates resolves the author's team code owners rules against the
+// changed files. It returns active owners in the order they were found and the
+// pattern that made each of them an owner.
+func (s *PullRequestService) codeOwnerCandidates(
+	ctx context.Context,
+	author *userdomain.User,
+	changedFiles []string,
+) ([]string, map[string]string, error) {
+	if len(changedFiles) == 0 || s.teams == nil {
+		return nil, nil, nil
+	}
+
+	ownerRules, err := s.teams.GetCodeOwners(ctx, author.TeamName)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to load code owners",
+				zap.String("team_name", author.TeamName),
+				zap.Error(err),
+			)
+		}
+		return nil, nil, err
+	}
+	if len(ownerRules) == 0 {
+		return nil, nil, nil
+	}
+
+	var candidates []string
+	matched := make(map[string]string)
+
+	add := func(u *userdomain.User, pattern string) {
+		if !u.IsActive || u.UserID == author.UserID {
+			return
+		}
+		if _, seen := matched[u.UserID]; seen {
+			return
+		}
+		matched[u.UserID] = pattern
+		candidates = append(candidates, u.UserID)
+	}
+
+	for _, file := range changedFiles {
+		rule, ok := teamdomain.MatchCodeOwners(ownerRules, file)
+		if !ok {
+			continue
+		}
+
+		for _, owner := range rule.Owners {
+			if ownerTeam, isTeam := strings.CutPrefix(owner, teamdomain.TeamOwnerPrefix); isTeam {
+				members, err := s.users.ListByTeam(ctx, ownerTeam)
+				if err != nil {
+					if s.logger != nil {
+						s.logger.Error("failed to list code owner team members",
+							zap.String("owner_team", ownerTeam),
+							zap.Error(err),
+						)
+					}
+					return nil, nil, err
+				}
+				for _, u := range members {
+					add(u, rule.Pattern)
+				}
+				continue
+			}
+			if owner == author.UserID {
+				continue
+			}
+
+			u, err := s.users.GetByID(ctx, owner)
+			if err != nil {
+				if errors.Is(err, userdomain.ErrUserNotFound) {
+					if s.logger != nil {
+						s.logger.Warn("code owner does not exist",
+							zap.String("owner", owner),
+							zap.String("pattern", rule.Pattern),
+						)
+					}
+					continue
+				}
+				return nil, nil, err
+			}
+			add(u, rule.Pattern)
+		}
+	}
+
+	return candidates, matched, nil
+}
+
+// available splits the candidates into those who can review now and those at
+// their review limit; see Availability.Split.
+func (s *PullRequestService) available(ctx context.Context, candidates []string) ([]string, []string, error) {
+	avail, err := s.availability.Check(ctx, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	free, full := avail.Split(candidates)
+	return free, full, nil
+}
+
+// teamSettings falls back to the default settings when the service was built
+// without a team repository.
+func (s *PullRequestService) teamSettings(ctx context.Context, teamName string) (teamdomain.Settings, error) {
+	if s.teams == nil {
+		return teamdomain.DefaultSettings(), nil
+	}
+
+	settings, err := s.teams.GetSettings(ctx, teamName)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to load team settings",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return teamdomain.Settings{}, err
+	}
+
+	return *settings, nil
+}
+
+// selectFallback picks up to count active reviewers outside teamName. Fallback teams
+// are consulted in order; with none configured every other team is a candidate.
+func (s *PullRequestService) selectFallback(
+	ctx context.Context,
+	teamName string,
+	fallbackTeams []string,
+	exclude []string,
+	count int,
+) ([]string, []string, error) {
+	if s.teams == nil {
+		return nil, nil, nil
+	}
+
+	if len(fallbackTeams) == 0 {
+		return s.selectFromOtherTeams(ctx, teamName, exclude, count)
+	}
+
+	var picked, atCapacity []string
+	for _, fb := range fallbackTeams {
+		if len(picked) == count {
+			break
+		}
+
+		members, err := s.users.ListByTeam(ctx, fb)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to list fallback team members",
+					zap.String("team_name", teamName),
+					zap.String("fallback_team", fb),
+					zap.Error(err),
+				)
+			}
+			return nil, nil, err
+		}
+
+		var candidates []string
+		for _, u := range members {
+			if !u.IsActive || slices.Contains(exclude, u.UserID) || slices.Contains(picked, u.UserID) {
+				continue
+			}
+			candidates = append(candidates, u.UserID)
+		}
+
+		candidates, full, err := s.available(ctx, candidates)
+		if err != nil {
+			return nil, nil, err
+		}
+		atCapacity = append(atCapacity, full...)
+
+		extra, err := s.selector.Select(ctx, fb, candidates, count-len(picked))
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to select fallback reviewers",
+					zap.String("fallback_team", fb),
+					zap.Error(err),
+				)
+			}
+			return nil, nil, err
+		}
+		picked = append(picked, extra...)
+	}
+
+	return picked, atCapacity, nil
+}
+
+func (s *PullRequestService) selectFromOtherTeams(
+	ctx context.Context,
+	teamName string,
+	exclude []string,
+	count int,
+) ([]string, []string, error) {
+	teams, err := s.teams.List(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list teams for cross-team reviewers",
+				zap.Error(err),
+			)
+		}
+		return nil, nil, err
+	}
+
+	var candidates []string
+	for _, t := range teams {
+		if t.TeamName == teamName {
+			continue
+		}
+		for _, m := range t.Members {
+			if !m.IsActive || slices.Contains(exclude, m.UserID) {
+				continue
+			}
+			candidates = append(candidates, m.UserID)
+		}
+	}
+
+	candidates, atCapacity, err := s.available(ctx, candidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	extra, err := s.selector.Select(ctx, teamName, candidates, count)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to select cross-team reviewers",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return nil, nil, err
+	}
+
+	return extra, atCapacity, nil
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+func (s *PullRequestService) GetPullRequest(ctx context.Context, id string) (*prdomain.PullRequest, error) {
+	pr, err := s.prs.GetByID(ctx, id)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to get pull request",
+				zap.String("pr_id", id),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// ListPullRequests returns one page of pull requests, newest first. NextCursor is
+// set only when there are more results after this page.
+func (s *PullRequestService) ListPullRequests(
+	ctx context.Context,
+	filter prdomain.ListFilter,
+) (*prdomain.PullRequestPage, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("%w: unknown status %q", prdomain.ErrInvalidFilter, filter.Status)
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return nil, fmt.Errorf("%w: created_from must be before created_to", prdomain.ErrInvalidFilter)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	filter.Limit = limit + 1
+
+	items, err := s.prs.List(ctx, filter)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list pull requests",
+				zap.Any("filter", filter),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	page := &prdomain.PullRequestPage{PullRequests: items}
+
+	if len(items) > limit {
+		page.PullRequests = items[:limit]
+		last := page.PullRequests[limit-1]
+		page.NextCursor = &prdomain.Cursor{
+			CreatedAt:     *last.CreatedAt,
+			PullRequestID: last.PullRequestID,
+		}
+	}
+
+	return page, nil
+}
+
+// MergePullRequest is idempotent: merging an already merged PR returns it
+// with the originally stored merged_at.
+func (s *PullRequestService) MergePullRequest(ctx context.Context, id string) (*prdomain.PullRequest, error) {
+	var updated *prdomain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.mergePullRequest(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *PullRequestService) mergePullRequest(ctx context.Context, id string) (*prdomain.PullRequest, error) {
+	if s.policy.enabled() {
+		if err := s.checkApprovals(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+
+	merged, err := s.prs.MarkMerged(ctx, id, now)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to mark PR as MERGED",
+				zap.String("pr_id", id),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	updated, err := s.prs.GetByID(ctx, id)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to reload PR after merge",
+				zap.String("pr_id", id),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if !merged && updated.Status != prdomain.PRStatusMerged {
+		if s.logger != nil {
+			s.logger.Warn("attempt to merge PR that is not open",
+				zap.String("pr_id", id),
+				zap.String("status", string(updated.Status)),
+			)
+		}
+		return nil, fmt.Errorf("%w: cannot merge %s pull request", prdomain.ErrInvalidStatusTransition, updated.Status)
+	}
+
+	if s.logger != nil {
+		if merged {
+			s.logger.Info("pull request merged",
+				zap.String("pr_id", id),
+			)
+		} else {
+			s.logger.Info("merge called on already merged PR",
+				zap.String("pr_id", id),
+			)
+		}
 	}
 
-	reviewers := pickRandom(candidates, 2)
-
-	pr := &prdomain.PullRequest{
-		PullRequestID:   id,
-		PullRequestName: name,
-		AuthorID:        authorID,
-		Status:          prdomain.PRStatusOpen,
-	}
+	return updated, nil
+}
 
-	if err := s.prs.Create(ctx, pr); err != nil {
+func (s *PullRequestService) checkApprovals(ctx context.Context, id string) error {
+	pr, err := s.prs.GetByID(ctx, id)
+	if err != nil {
 		if s.logger != nil {
-			s.logger.Error("failed to create pull request",
+			s.logger.Error("failed to get PR for approval check",
 				zap.String("pr_id", id),
 				zap.Error(err),
 			)
 		}
-		return nil, err
+		return err
+	}
+
+	if pr.Status != prdomain.PRStatusOpen {
+		return nil
 	}
 
-	if err := s.prs.SetReviewers(ctx, id, reviewers); err != nil {
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
 		if s.logger != nil {
-			s.logger.Error("failed to set reviewers after PR creation",
+			s.logger.Error("failed to load PR author for approval check",
 				zap.String("pr_id", id),
-				zap.Any("reviewers", reviewers),
+				zap.String("author_id", pr.AuthorID),
 				zap.Error(err),
 			)
 		}
-		return nil, err
+		return err
 	}
 
-	created, err := s.prs.GetByID(ctx, id)
-	if err != nil {
+	required := s.policy.Required(author.TeamName)
+	approvals := pr.Approvals()
+
+	if approvals < required {
 		if s.logger != nil {
-			s.logger.Error("failed to reload PR after creation",
+			s.logger.Warn("merge refused: not enough approvals",
 				zap.String("pr_id", id),
-				zap.Error(err),
+				zap.String("team_name", author.TeamName),
+				zap.Int("approvals", approvals),
+				zap.Int("required", required),
 			)
 		}
-		return nil, err
+		return fmt.Errorf("%w: %d of %d", prdomain.ErrNotEnoughApprovals, approvals, required)
 	}
 
-	if s.logger != nil {
-		s.logger.Info("pull request created",
-			zap.String("pr_id", id),
-			zap.String("author_id", authorID),
-			zap.Strings("reviewers", reviewers),
-		)
+	return nil
+}
+
+// SubmitReview records the reviewer's decision; a new decision replaces the previous one.
+func (s *PullRequestService) SubmitReview(
+	ctx context.Context,
+	prID string,
+	reviewerID string,
+	decision prdomain.ReviewDecision,
+) (*prdomain.PullRequest, error) {
+	if !decision.Valid() {
+		return nil, fmt.Errorf("%w: %q", prdomain.ErrInvalidDecision, decision)
 	}
 
-	return created, nil
+	var updated *prdomain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.submitReview(ctx, prID, reviewerID, decision)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-func (s *PullRequestService) MergePullRequest(ctx context.Context, id string) (*prdomain.PullRequest, error) {
-	pr, err := s.prs.GetByID(ctx, id)
+func (s *PullRequestService) submitReview(
+	ctx context.Context,
+	prID string,
+	reviewerID string,
+	decision prdomain.ReviewDecision,
+) (*prdomain.PullRequest, error) {
+	pr, err := s.prs.GetByID(ctx, prID)
 	if err != nil {
 		if s.logger != nil {
-			s.logger.Error("failed to get PR for merge",
-				zap.String("pr_id", id),
+			s.logger.Error("failed to get PR for review",
+				zap.String("pr_id", prID),
 				zap.Error(err),
 			)
 		}
@@ -135,31 +772,44 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, id string) (*
 	}
 
 	if pr.Status == prdomain.PRStatusMerged {
+		return nil, prdomain.ErrPullRequestMerged
+	}
+	if pr.Status != prdomain.PRStatusOpen {
+		return nil, prdomain.ErrPullRequestNotOpen
+	}
+
+	if !slices.Contains(pr.AssignedReviewers, reviewerID) {
 		if s.logger != nil {
-			s.logger.Info("merge called on already merged PR",
-				zap.String("pr_id", id),
+			s.logger.Warn("review submitted by unassigned user",
+				zap.String("pr_id", prID),
+				zap.String("reviewer_id", reviewerID),
 			)
 		}
-		return nil, prdomain.ErrPullRequestMerged
+		return nil, prdomain.ErrReviewerNotAssigned
 	}
 
-	now := time.Now().UTC()
+	review := prdomain.Review{
+		ReviewerID:  reviewerID,
+		Decision:    decision,
+		SubmittedAt: time.Now().UTC(),
+	}
 
-	if err := s.prs.UpdateStatus(ctx, id, prdomain.PRStatusMerged, &now); err != nil {
+	if err := s.prs.SaveReview(ctx, prID, review); err != nil {
 		if s.logger != nil {
-			s.logger.Error("failed to update PR status to MERGED",
-				zap.String("pr_id", id),
+			s.logger.Error("failed to save review",
+				zap.String("pr_id", prID),
+				zap.String("reviewer_id", reviewerID),
 				zap.Error(err),
 			)
 		}
 		return nil, err
 	}
 
-	updated, err := s.prs.GetByID(ctx, id)
+	updated, err := s.prs.GetByID(ctx, prID)
 	if err != nil {
 		if s.logger != nil {
-			s.logger.Error("failed to reload PR after merge",
-				zap.String("pr_id", id),
+			s.logger.Error("failed to reload PR after review",
+				zap.String("pr_id", prID),
 				zap.Error(err),
 			)
 		}
@@ -167,18 +817,50 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, id string) (*
 	}
 
 	if s.logger != nil {
-		s.logger.Info("pull request merged",
-			zap.String("pr_id", id),
+		s.logger.Info("review submitted",
+			zap.String("pr_id", prID),
+			zap.String("reviewer_id", reviewerID),
+			zap.String("decision", string(decision)),
 		)
 	}
 
 	return updated, nil
 }
 
+// ReassignReviewer replaces oldReviewerID on the pull request with newReviewerID,
+// or with a reviewer chosen by the service when newReviewerID is empty. With
+// dryRun nothing is written: the returned pull request shows the planned reviewers.
 func (s *PullRequestService) ReassignReviewer(
 	ctx context.Context,
 	prID string,
 	oldReviewerID string,
+	newReviewerID string,
+	dryRun bool,
+) (*prdomain.PullRequest, string, error) {
+	var updated *prdomain.PullRequest
+
+	if dryRun {
+		ctx = WithDryRun(ctx)
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		updated, newReviewerID, err = s.reassignReviewer(ctx, prID, oldReviewerID, newReviewerID, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return updated, newReviewerID, nil
+}
+
+func (s *PullRequestService) reassignReviewer(
+	ctx context.Context,
+	prID string,
+	oldReviewerID string,
+	newReviewerID string,
+	dryRun bool,
 ) (*prdomain.PullRequest, string, error) {
 	pr, err := s.prs.GetByID(ctx, prID)
 	if err != nil {
@@ -199,15 +881,11 @@ func (s *PullRequestService) ReassignReviewer(
 		}
 		return nil, "", prdomain.ErrPullRequestMerged
 	}
-
-	found := false
-	for _, rID := range pr.AssignedReviewers {
-		if rID == oldReviewerID {
-			found = true
-			break
-		}
+	if pr.Status != prdomain.PRStatusOpen {
+		return nil, "", prdomain.ErrPullRequestNotOpen
 	}
-	if !found {
+
+	if !slices.Contains(pr.AssignedReviewers, oldReviewerID) {
 		if s.logger != nil {
 			s.logger.Warn("old reviewer is not assigned to PR",
 				zap.String("pr_id", prID),
@@ -217,6 +895,95 @@ func (s *PullRequestService) ReassignReviewer(
 		return nil, "", prdomain.ErrReviewerNotAssigned
 	}
 
+	var isFallback bool
+	manual := newReviewerID != ""
+	if !manual {
+		newReviewerID, isFallback, err = s.pickReplacement(ctx, pr, oldReviewerID)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := pr.ReplaceReviewer(oldReviewerID, newReviewerID); err != nil {
+		if s.logger != nil {
+			s.logger.Warn("reviewer cannot take over review",
+				zap.String("pr_id", prID),
+				zap.String("new_reviewer_id", newReviewerID),
+				zap.Error(err),
+			)
+		}
+		return nil, "", err
+	}
+	if manual {
+		if err := s.checkReviewerActive(ctx, newReviewerID); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if dryRun {
+		if isFallback {
+			pr.FallbackReviewers = []string{newReviewerID}
+		}
+
+		if s.logger != nil {
+			s.logger.Info("reviewer reassign planned (dry run)",
+				zap.String("pr_id", prID),
+				zap.String("old_reviewer_id", oldReviewerID),
+				zap.String("new_reviewer_id", newReviewerID),
+				zap.Bool("fallback", isFallback),
+			)
+		}
+
+		return pr, newReviewerID, nil
+	}
+
+	if err := s.prs.SetReviewers(ctx, prID, pr.AssignedReviewers); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to update reviewers on reassign",
+				zap.String("pr_id", prID),
+				zap.Strings("new_reviewers", pr.AssignedReviewers),
+				zap.Error(err),
+			)
+		}
+		return nil, "", err
+	}
+
+	updated, err := s.prs.GetByID(ctx, prID)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to reload PR after reassign",
+				zap.String("pr_id", prID),
+				zap.Error(err),
+			)
+		}
+		return nil, "", err
+	}
+	if isFallback {
+		updated.FallbackReviewers = []string{newReviewerID}
+	}
+
+	if s.logger != nil {
+		s.logger.Info("reviewer reassigned",
+			zap.String("pr_id", prID),
+			zap.String("old_reviewer_id", oldReviewerID),
+			zap.String("new_reviewer_id", newReviewerID),
+			zap.Bool("fallback", isFallback),
+		)
+	}
+
+	return updated, newReviewerID, nil
+}
+
+// pickReplacement chooses who takes oldReviewerID's place: an available member
+// of the old reviewer's team, or someone from the fallback teams when the team
+// has nobody left. It reports whether the pick came from a fallback team.
+func (s *PullRequestService) pickReplacement(
+	ctx context.Context,
+	pr *prdomain.PullRequest,
+	oldReviewerID string,
+) (string, bool, error) {
+	prID := pr.PullRequestID
+
 	oldReviewer, err := s.users.GetByID(ctx, oldReviewerID)
 	if err != nil {
 		if s.logger != nil {
@@ -225,7 +992,7 @@ func (s *PullRequestService) ReassignReviewer(
 				zap.Error(err),
 			)
 		}
-		return nil, "", err
+		return "", false, err
 	}
 	teamName := oldReviewer.TeamName
 
@@ -237,7 +1004,7 @@ func (s *PullRequestService) ReassignReviewer(
 				zap.Error(err),
 			)
 		}
-		return nil, "", err
+		return "", false, err
 	}
 
 	assignedSet := make(map[string]struct{}, len(pr.AssignedReviewers))
@@ -250,7 +1017,7 @@ func (s *PullRequestService) ReassignReviewer(
 		if !u.IsActive {
 			continue
 		}
-		if u.UserID == oldReviewerID {
+		if u.UserID == oldReviewerID || u.UserID == pr.AuthorID {
 			continue
 		}
 		if _, already := assignedSet[u.UserID]; already {
@@ -259,82 +1026,310 @@ func (s *PullRequestService) ReassignReviewer(
 		candidates = append(candidates, u.UserID)
 	}
 
-	if len(candidates) == 0 {
+	candidates, atCapacity, err := s.available(ctx, candidates)
+	if err != nil {
+		return "", false, err
+	}
+
+	var (
+		picked     []string
+		isFallback bool
+	)
+
+	if len(candidates) > 0 {
+		picked, err = s.selector.Select(ctx, teamName, candidates, 1)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to select reviewer for reassign",
+					zap.String("team_name", teamName),
+					zap.Error(err),
+				)
+			}
+			return "", false, err
+		}
+	} else {
+		settings, err := s.teamSettings(ctx, teamName)
+		if err != nil {
+			return "", false, err
+		}
+
+		if !settings.SelfTeamOnly {
+			exclude := append([]string{pr.AuthorID, oldReviewerID}, pr.AssignedReviewers...)
+			var full []string
+			picked, full, err = s.selectFallback(ctx, teamName, settings.FallbackTeams, exclude, 1)
+			if err != nil {
+				return "", false, err
+			}
+			atCapacity = append(atCapacity, full...)
+			isFallback = true
+		}
+	}
+
+	if len(picked) == 0 {
 		if s.logger != nil {
 			s.logger.Warn("no candidate for reviewer reassign",
 				zap.String("pr_id", prID),
 				zap.String("old_reviewer_id", oldReviewerID),
+				zap.Strings("at_capacity", atCapacity),
 			)
 		}
-		return nil, "", prdomain.ErrNoCandidate
+		if len(atCapacity) > 0 {
+			return "", false, fmt.Errorf("%w: %v", prdomain.ErrReviewersAtCapacity, atCapacity)
+		}
+		return "", false, prdomain.ErrNoCandidate
 	}
 
-	newReviewerID := pickRandom(candidates, 1)[0]
+	return picked[0], isFallback, nil
+}
 
-	newReviewers := make([]string, len(pr.AssignedReviewers))
-	for i, rID := range pr.AssignedReviewers {
-		if rID == oldReviewerID {
-			newReviewers[i] = newReviewerID
-		} else {
-			newReviewers[i] = rID
-		}
+// checkReviewerActive makes sure a reviewer chosen by hand exists and is active.
+func (s *PullRequestService) checkReviewerActive(ctx context.Context, userID string) error {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !u.IsActive {
+		return prdomain.ErrReviewerInactive
 	}
 
-	if err := s.prs.SetReviewers(ctx, prID, newReviewers); err != nil {
-		if s.logger != nil {
-			s.logger.Error("failed to update reviewers on reassign",
-				zap.String("pr_id", prID),
-				zap.Strings("new_reviewers", newReviewers),
-				zap.Error(err),
-			)
+	return nil
+}
+
+// AddReviewer assigns one more reviewer to an OPEN pull request by hand.
+func (s *PullRequestService) AddReviewer(ctx context.Context, prID string, reviewerID string) (*prdomain.PullRequest, error) {
+	return s.adjustReviewers(ctx, prID, func(pr *prdomain.PullRequest) error {
+		if err := pr.AddReviewer(reviewerID); err != nil {
+			return err
 		}
-		return nil, "", err
-	}
+		return s.checkReviewerActive(ctx, reviewerID)
+	})
+}
 
-	updated, err := s.prs.GetByID(ctx, prID)
+// RemoveReviewer unassigns a reviewer from an OPEN pull request by hand; their
+// review decision is dropped together with the assignment.
+func (s *PullRequestService) RemoveReviewer(ctx context.Context, prID string, reviewerID string) (*prdomain.PullRequest, error) {
+	return s.adjustReviewers(ctx, prID, func(pr *prdomain.PullRequest) error {
+		return pr.RemoveReviewer(reviewerID)
+	})
+}
+
+// adjustReviewers applies change to the reviewers of an OPEN pull request, stores
+// the result and returns the reloaded pull request.
+func (s *PullRequestService) adjustReviewers(
+	ctx context.Context,
+	prID string,
+	change func(pr *prdomain.PullRequest) error,
+) (*prdomain.PullRequest, error) {
+	var updated *prdomain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prs.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == prdomain.PRStatusMerged {
+			return prdomain.ErrPullRequestMerged
+		}
+		if pr.Status != prdomain.PRStatusOpen {
+			return prdomain.ErrPullRequestNotOpen
+		}
+
+		if err := change(pr); err != nil {
+			return err
+		}
+
+		if err := s.prs.SetReviewers(ctx, prID, pr.AssignedReviewers); err != nil {
+			return err
+		}
+
+		updated, err = s.prs.GetByID(ctx, prID)
+		return err
+	})
 	if err != nil {
 		if s.logger != nil {
-			s.logger.Error("failed to reload PR after reassign",
+			s.logger.Warn("failed to adjust reviewers",
 				zap.String("pr_id", prID),
 				zap.Error(err),
 			)
 		}
-		return nil, "", err
+		return nil, err
 	}
 
 	if s.logger != nil {
-		s.logger.Info("reviewer reassigned",
+		s.logger.Info("reviewers adjusted",
 			zap.String("pr_id", prID),
-			zap.String("old_reviewer_id", oldReviewerID),
-			zap.String("new_reviewer_id", newReviewerID),
+			zap.Strings("reviewers", updated.AssignedReviewers),
 		)
 	}
 
-	return updated, newReviewerID, nil
+	return updated, nil
 }
 
-func pickRandom(src []string, n int) []string {
-	if n <= 0 || len(src) == 0 {
+// MarkReady moves a DRAFT pull request to OPEN and assigns reviewers.
+func (s *PullRequestService) MarkReady(ctx context.Context, id string) (*prdomain.PullRequest, error) {
+	return s.openWithReviewers(ctx, id, prdomain.PRStatusDraft)
+}
+
+// ReopenPullRequest moves a CLOSED pull request back to OPEN and assigns reviewers again.
+func (s *PullRequestService) ReopenPullRequest(ctx context.Context, id string) (*prdomain.PullRequest, error) {
+	return s.openWithReviewers(ctx, id, prdomain.PRStatusClosed)
+}
+
+func (s *PullRequestService) openWithReviewers(
+	ctx context.Context,
+	id string,
+	from prdomain.PRStatus,
+) (*prdomain.PullRequest, error) {
+	var updated *prdomain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prs.GetByID(ctx, id)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to get PR for status change",
+					zap.String("pr_id", id),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+
+		if pr.Status != from {
+			if pr.Status == prdomain.PRStatusMerged {
+				return prdomain.ErrPullRequestMerged
+			}
+			return fmt.Errorf("%w: expected %s, got %s", prdomain.ErrInvalidStatusTransition, from, pr.Status)
+		}
+
+		author, err := s.users.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to load PR author for status change",
+					zap.String("pr_id", id),
+					zap.String("author_id", pr.AuthorID),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+
+		assignment, err := s.selectInitialReviewers(ctx, author, pr.ChangedFiles)
+		if err != nil {
+			return err
+		}
+		reviewers := assignment.reviewers
+		if err := pr.AssignReviewers(reviewers); err != nil {
+			return err
+		}
+
+		if err := s.changeStatus(ctx, pr, prdomain.PRStatusOpen); err != nil {
+			return err
+		}
+
+		if err := s.prs.SetReviewers(ctx, id, pr.AssignedReviewers); err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to set reviewers on status change",
+					zap.String("pr_id", id),
+					zap.Strings("reviewers", reviewers),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+
+		updated, err = s.prs.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		assignment.apply(updated)
+
 		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if len(src) <= n {
-		out := make([]string, len(src))
-		copy(out, src)
-		return out
+
+	return updated, nil
+}
+
+// ClosePullRequest abandons a DRAFT or OPEN pull request and releases its reviewers.
+func (s *PullRequestService) ClosePullRequest(ctx context.Context, id string) (*prdomain.PullRequest, error) {
+	var updated *prdomain.PullRequest
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prs.GetByID(ctx, id)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to get PR for close",
+					zap.String("pr_id", id),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+
+		if err := s.changeStatus(ctx, pr, prdomain.PRStatusClosed); err != nil {
+			return err
+		}
+		if err := pr.AssignReviewers(nil); err != nil {
+			return err
+		}
+
+		if err := s.prs.SetReviewers(ctx, id, pr.AssignedReviewers); err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to release reviewers on close",
+					zap.String("pr_id", id),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+
+		updated, err = s.prs.GetByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	used := make(map[int]struct{})
-	res := make([]string, 0, n)
+	return updated, nil
+}
+
+func (s *PullRequestService) changeStatus(ctx context.Context, pr *prdomain.PullRequest, to prdomain.PRStatus) error {
+	if err := pr.CheckTransition(to); err != nil {
+		if s.logger != nil {
+			s.logger.Warn("invalid PR status transition",
+				zap.String("pr_id", pr.PullRequestID),
+				zap.String("from", string(pr.Status)),
+				zap.String("to", string(to)),
+			)
+		}
+		return err
+	}
 
-	for len(res) < n {
-		idx, _ := rand.Int(rand.Reader, big.NewInt(int64(len(src))))
-		i := int(idx.Int64())
-		if _, ok := used[i]; ok {
-			continue
+	changed, err := s.prs.ChangeStatus(ctx, pr.PullRequestID, pr.Status, to)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to change PR status",
+				zap.String("pr_id", pr.PullRequestID),
+				zap.String("to", string(to)),
+				zap.Error(err),
+			)
 		}
-		used[i] = struct{}{}
-		res = append(res, src[i])
+		return err
+	}
+	if !changed {
+		return fmt.Errorf("%w: %s changed concurrently", prdomain.ErrInvalidStatusTransition, pr.PullRequestID)
+	}
+
+	if s.logger != nil {
+		s.logger.Info("pull request status changed",
+			zap.String("pr_id", pr.PullRequestID),
+			zap.String("from", string(pr.Status)),
+			zap.String("to", string(to)),
+		)
 	}
 
-	return res
+	return nil
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
 	prmocks "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/mocks"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teammocks "github.com/dunooo0ooo/avito-test-task/internal/team/mocks"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
 	usermocks "github.com/dunooo0ooo/avito-test-task/internal/user/mocks"
 	"github.com/golang/mock/gomock"
@@ -21,9 +24,11 @@ func TestCreatePullRequest_Success(t *testing.T) {
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	author := &userdomain.User{
@@ -69,7 +74,7 @@ func TestCreatePullRequest_Success(t *testing.T) {
 		GetByID(gomock.Any(), "pr-1").
 		Return(expectedPR, nil)
 
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
 	require.NoError(t, err)
 	require.NotNil(t, pr)
 
@@ -87,14 +92,14 @@ func TestCreatePullRequest_AuthorNotFound(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	userRepo.EXPECT().
 		GetByID(gomock.Any(), "u1").
 		Return(nil, userdomain.ErrUserNotFound)
 
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, userdomain.ErrUserNotFound))
 	assert.Nil(t, pr)
@@ -108,7 +113,7 @@ func TestCreatePullRequest_ListTeamError(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	author := &userdomain.User{
@@ -127,7 +132,7 @@ func TestCreatePullRequest_ListTeamError(t *testing.T) {
 		ListByTeam(gomock.Any(), "backend").
 		Return(nil, expectedErr)
 
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, pr)
@@ -141,23 +146,33 @@ func TestMergePullRequest_AlreadyMerged(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
+	mergedAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
 	existing := &prdomain.PullRequest{
 		PullRequestID:   "pr-1",
 		PullRequestName: "Add search",
 		AuthorID:        "u1",
 		Status:          prdomain.PRStatusMerged,
+		MergedAt:        &mergedAt,
 	}
 
-	prRepo.EXPECT().
-		GetByID(gomock.Any(), "pr-1").
-		Return(existing, nil)
+	gomock.InOrder(
+		prRepo.EXPECT().
+			MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+			Return(false, nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(existing, nil),
+	)
 
 	pr, err := svc.MergePullRequest(ctx, "pr-1")
-	require.Error(t, err)
-	require.Nil(t, pr)
+	require.NoError(t, err)
+	require.NotNil(t, pr)
+
+	assert.Equal(t, prdomain.PRStatusMerged, pr.Status)
+	assert.Equal(t, &mergedAt, pr.MergedAt)
 }
 
 func TestMergePullRequest_FromOpenToMerged(t *testing.T) {
@@ -168,16 +183,9 @@ func TestMergePullRequest_FromOpenToMerged(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
-	openPR := &prdomain.PullRequest{
-		PullRequestID:   "pr-1",
-		PullRequestName: "Add search",
-		AuthorID:        "u1",
-		Status:          prdomain.PRStatusOpen,
-	}
-
 	mergedPR := &prdomain.PullRequest{
 		PullRequestID:   "pr-1",
 		PullRequestName: "Add search",
@@ -187,11 +195,8 @@ func TestMergePullRequest_FromOpenToMerged(t *testing.T) {
 
 	gomock.InOrder(
 		prRepo.EXPECT().
-			GetByID(gomock.Any(), "pr-1").
-			Return(openPR, nil),
-		prRepo.EXPECT().
-			UpdateStatus(gomock.Any(), "pr-1", prdomain.PRStatusMerged, gomock.Any()).
-			Return(nil),
+			MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+			Return(true, nil),
 		prRepo.EXPECT().
 			GetByID(gomock.Any(), "pr-1").
 			Return(mergedPR, nil),
@@ -204,7 +209,7 @@ func TestMergePullRequest_FromOpenToMerged(t *testing.T) {
 	assert.Equal(t, prdomain.PRStatusMerged, pr.Status)
 }
 
-func TestMergePullRequest_GetByIDError(t *testing.T) {
+func TestMergePullRequest_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -212,18 +217,21 @@ func TestMergePullRequest_GetByIDError(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
-	expectedErr := errors.New("db error")
-
-	prRepo.EXPECT().
-		GetByID(gomock.Any(), "pr-1").
-		Return(nil, expectedErr)
+	gomock.InOrder(
+		prRepo.EXPECT().
+			MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+			Return(false, nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(nil, prdomain.ErrPullRequestNotFound),
+	)
 
 	pr, err := svc.MergePullRequest(ctx, "pr-1")
 	require.Error(t, err)
-	assert.True(t, errors.Is(err, expectedErr))
+	assert.True(t, errors.Is(err, prdomain.ErrPullRequestNotFound))
 	assert.Nil(t, pr)
 }
 
@@ -233,9 +241,11 @@ func TestReassignReviewer_Success(t *testing.T) {
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	pr := &prdomain.PullRequest{
@@ -284,7 +294,7 @@ func TestReassignReviewer_Success(t *testing.T) {
 			Return(updatedPR, nil),
 	)
 
-	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.NoError(t, err)
 	require.NotNil(t, resPR)
 
@@ -293,6 +303,44 @@ func TestReassignReviewer_Success(t *testing.T) {
 	assert.Contains(t, []string{"u4"}, newReviewer)
 }
 
+func TestReassignReviewer_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews: []prdomain.Review{
+			{ReviewerID: "u2", Decision: prdomain.ReviewApproved},
+			{ReviewerID: "u3", Decision: prdomain.ReviewCommented},
+		},
+	}
+
+	userOld := &userdomain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+
+	prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(pr, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "u2").Return(userOld, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{userOld, {UserID: "u4", TeamName: "backend", IsActive: true}}, nil)
+
+	planned, newReviewer, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, "u4", newReviewer)
+	assert.Equal(t, []string{"u4", "u3"}, planned.AssignedReviewers)
+	require.Len(t, planned.Reviews, 1)
+	assert.Equal(t, "u3", planned.Reviews[0].ReviewerID)
+}
+
 func TestReassignReviewer_OnMergedPR(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -301,7 +349,7 @@ func TestReassignReviewer_OnMergedPR(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	pr := &prdomain.PullRequest{
@@ -315,7 +363,7 @@ func TestReassignReviewer_OnMergedPR(t *testing.T) {
 		GetByID(gomock.Any(), "pr-1").
 		Return(pr, nil)
 
-	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, prdomain.ErrPullRequestMerged))
 	assert.Nil(t, resPR)
@@ -330,7 +378,7 @@ func TestReassignReviewer_OldNotAssigned(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	pr := &prdomain.PullRequest{
@@ -345,7 +393,7 @@ func TestReassignReviewer_OldNotAssigned(t *testing.T) {
 		GetByID(gomock.Any(), "pr-1").
 		Return(pr, nil)
 
-	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, prdomain.ErrReviewerNotAssigned))
 	assert.Nil(t, resPR)
@@ -360,7 +408,7 @@ func TestReassignReviewer_NoCandidate(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	pr := &prdomain.PullRequest{
@@ -394,7 +442,7 @@ func TestReassignReviewer_NoCandidate(t *testing.T) {
 			Return(teamMembers, nil),
 	)
 
-	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, prdomain.ErrNoCandidate))
 	assert.Nil(t, resPR)
@@ -407,8 +455,10 @@ func TestCreatePullRequest_CreateError(t *testing.T) {
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	author := &userdomain.User{
@@ -435,7 +485,7 @@ func TestCreatePullRequest_CreateError(t *testing.T) {
 		Create(gomock.Any(), gomock.Any()).
 		Return(expectedErr)
 
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, pr)
@@ -447,8 +497,10 @@ func TestCreatePullRequest_SetReviewersError(t *testing.T) {
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	author := &userdomain.User{
@@ -479,7 +531,7 @@ func TestCreatePullRequest_SetReviewersError(t *testing.T) {
 		SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
 		Return(expectedErr)
 
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, pr)
@@ -491,8 +543,10 @@ func TestCreatePullRequest_GetByIDAfterCreateError(t *testing.T) {
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	author := &userdomain.User{
@@ -528,39 +582,27 @@ func TestCreatePullRequest_GetByIDAfterCreateError(t *testing.T) {
 		GetByID(gomock.Any(), "pr-1").
 		Return(nil, expectedErr)
 
-	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, pr)
 }
 
-func TestMergePullRequest_UpdateStatusError(t *testing.T) {
+func TestMergePullRequest_MarkMergedError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
-	openPR := &prdomain.PullRequest{
-		PullRequestID:   "pr-1",
-		PullRequestName: "Add search",
-		AuthorID:        "u1",
-		Status:          prdomain.PRStatusOpen,
-	}
-
 	expectedErr := errors.New("update error")
 
-	gomock.InOrder(
-		prRepo.EXPECT().
-			GetByID(gomock.Any(), "pr-1").
-			Return(openPR, nil),
-		prRepo.EXPECT().
-			UpdateStatus(gomock.Any(), "pr-1", prdomain.PRStatusMerged, gomock.Any()).
-			Return(expectedErr),
-	)
+	prRepo.EXPECT().
+		MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+		Return(false, expectedErr)
 
 	pr, err := svc.MergePullRequest(ctx, "pr-1")
 	require.Error(t, err)
@@ -575,25 +617,15 @@ func TestMergePullRequest_GetAfterUpdateError(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
-	openPR := &prdomain.PullRequest{
-		PullRequestID:   "pr-1",
-		PullRequestName: "Add search",
-		AuthorID:        "u1",
-		Status:          prdomain.PRStatusOpen,
-	}
-
 	expectedErr := errors.New("get after update error")
 
 	gomock.InOrder(
 		prRepo.EXPECT().
-			GetByID(gomock.Any(), "pr-1").
-			Return(openPR, nil),
-		prRepo.EXPECT().
-			UpdateStatus(gomock.Any(), "pr-1", prdomain.PRStatusMerged, gomock.Any()).
-			Return(nil),
+			MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+			Return(true, nil),
 		prRepo.EXPECT().
 			GetByID(gomock.Any(), "pr-1").
 			Return(nil, expectedErr),
@@ -612,7 +644,7 @@ func TestReassignReviewer_GetPRByIDError(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	expectedErr := errors.New("get pr error")
@@ -621,7 +653,7 @@ func TestReassignReviewer_GetPRByIDError(t *testing.T) {
 		GetByID(gomock.Any(), "pr-1").
 		Return(nil, expectedErr)
 
-	resPR, newRev, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newRev, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, resPR)
@@ -635,7 +667,7 @@ func TestReassignReviewer_GetOldReviewerError(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	pr := &prdomain.PullRequest{
@@ -656,7 +688,7 @@ func TestReassignReviewer_GetOldReviewerError(t *testing.T) {
 		GetByID(gomock.Any(), "u2").
 		Return(nil, expectedErr)
 
-	resPR, newRev, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newRev, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, resPR)
@@ -669,8 +701,10 @@ func TestReassignReviewer_SetReviewersError(t *testing.T) {
 
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	logger := zap.NewNop()
-	svc := NewPullRequestService(prRepo, userRepo, logger)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, logger)
 	ctx := context.Background()
 
 	pr := &prdomain.PullRequest{
@@ -710,9 +744,1238 @@ func TestReassignReviewer_SetReviewersError(t *testing.T) {
 			Return(expectedErr),
 	)
 
-	resPR, newRev, err := svc.ReassignReviewer(ctx, "pr-1", "u2")
+	resPR, newRev, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, resPR)
 	assert.Equal(t, "", newRev)
 }
+
+type recordingTx struct {
+	calls int
+}
+
+func (r *recordingTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.calls++
+	return fn(ctx)
+}
+
+func TestCreatePullRequest_RunsInTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	tx := &recordingTx{}
+	svc := NewPullRequestService(prRepo, userRepo, nil, tx, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(author, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{author}, nil)
+
+	expectedErr := errors.New("set reviewers error")
+	gomock.InOrder(
+		prRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(nil),
+		prRepo.EXPECT().
+			SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+			Return(expectedErr),
+	)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Add search", "u1", false, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, expectedErr))
+	assert.Nil(t, pr)
+	assert.Equal(t, 1, tx.calls)
+}
+
+func TestMergePullRequest_NotEnoughApprovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	policy := MergePolicy{
+		RequiredApprovals:     1,
+		TeamRequiredApprovals: map[string]int{"backend": 2},
+	}
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, policy, zap.NewNop())
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews: []prdomain.Review{
+			{ReviewerID: "u2", Decision: prdomain.ReviewApproved},
+			{ReviewerID: "u3", Decision: prdomain.ReviewChangesRequested},
+		},
+	}
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(pr, nil)
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend"}, nil)
+
+	res, err := svc.MergePullRequest(context.Background(), "pr-1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrNotEnoughApprovals))
+	assert.Nil(t, res)
+}
+
+func TestMergePullRequest_Approved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	policy := MergePolicy{
+		RequiredApprovals:     2,
+		TeamRequiredApprovals: map[string]int{"backend": 1},
+	}
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, policy, zap.NewNop())
+
+	openPR := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+		Reviews: []prdomain.Review{
+			{ReviewerID: "u2", Decision: prdomain.ReviewApproved},
+		},
+	}
+	mergedPR := *openPR
+	mergedPR.Status = prdomain.PRStatusMerged
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(openPR, nil),
+		prRepo.EXPECT().
+			MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+			Return(true, nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(&mergedPR, nil),
+	)
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend"}, nil)
+
+	res, err := svc.MergePullRequest(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, prdomain.PRStatusMerged, res.Status)
+}
+
+func TestSubmitReview_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	reviewed := *pr
+	reviewed.Reviews = []prdomain.Review{{ReviewerID: "u2", Decision: prdomain.ReviewApproved}}
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(pr, nil),
+		prRepo.EXPECT().
+			SaveReview(gomock.Any(), "pr-1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, rv prdomain.Review) error {
+				assert.Equal(t, "u2", rv.ReviewerID)
+				assert.Equal(t, prdomain.ReviewApproved, rv.Decision)
+				assert.False(t, rv.SubmittedAt.IsZero())
+				return nil
+			}),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(&reviewed, nil),
+	)
+
+	res, err := svc.SubmitReview(context.Background(), "pr-1", "u2", prdomain.ReviewApproved)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Approvals())
+}
+
+func TestSubmitReview_InvalidDecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	res, err := svc.SubmitReview(context.Background(), "pr-1", "u2", "LGTM")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrInvalidDecision))
+	assert.Nil(t, res)
+}
+
+func TestSubmitReview_NotAssigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{
+			PullRequestID:     "pr-1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u3"},
+		}, nil)
+
+	res, err := svc.SubmitReview(context.Background(), "pr-1", "u2", prdomain.ReviewCommented)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrReviewerNotAssigned))
+	assert.Nil(t, res)
+}
+
+func TestSubmitReview_OnMergedPR(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{
+			PullRequestID:     "pr-1",
+			Status:            prdomain.PRStatusMerged,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+
+	res, err := svc.SubmitReview(context.Background(), "pr-1", "u2", prdomain.ReviewApproved)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrPullRequestMerged))
+	assert.Nil(t, res)
+}
+
+func TestCreatePullRequest_Draft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	draft := &prdomain.PullRequest{
+		PullRequestID: "pr-1",
+		AuthorID:      "u1",
+		Status:        prdomain.PRStatusDraft,
+	}
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, pr *prdomain.PullRequest) error {
+				assert.Equal(t, prdomain.PRStatusDraft, pr.Status)
+				return nil
+			}),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(draft, nil),
+	)
+
+	pr, err := svc.CreatePullRequest(context.Background(), "pr-1", "Add search", "u1", true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, prdomain.PRStatusDraft, pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+}
+
+func TestMarkReady_AssignsReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	draft := &prdomain.PullRequest{
+		PullRequestID: "pr-1",
+		AuthorID:      "u1",
+		Status:        prdomain.PRStatusDraft,
+	}
+	opened := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "u2", TeamName: "backend", IsActive: true},
+		}, nil)
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(draft, nil),
+		prRepo.EXPECT().
+			ChangeStatus(gomock.Any(), "pr-1", prdomain.PRStatusDraft, prdomain.PRStatusOpen).
+			Return(true, nil),
+		prRepo.EXPECT().
+			SetReviewers(gomock.Any(), "pr-1", []string{"u2"}).
+			Return(nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(opened, nil),
+	)
+
+	pr, err := svc.MarkReady(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, prdomain.PRStatusOpen, pr.Status)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestMarkReady_NotDraft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusClosed}, nil)
+
+	pr, err := svc.MarkReady(context.Background(), "pr-1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrInvalidStatusTransition))
+	assert.Nil(t, pr)
+}
+
+func TestClosePullRequest_ReleasesReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	open := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	closed := &prdomain.PullRequest{
+		PullRequestID: "pr-1",
+		Status:        prdomain.PRStatusClosed,
+	}
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(open, nil),
+		prRepo.EXPECT().
+			ChangeStatus(gomock.Any(), "pr-1", prdomain.PRStatusOpen, prdomain.PRStatusClosed).
+			Return(true, nil),
+		prRepo.EXPECT().
+			SetReviewers(gomock.Any(), "pr-1", nil).
+			Return(nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(closed, nil),
+	)
+
+	pr, err := svc.ClosePullRequest(context.Background(), "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, prdomain.PRStatusClosed, pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+}
+
+func TestClosePullRequest_Merged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusMerged}, nil)
+
+	pr, err := svc.ClosePullRequest(context.Background(), "pr-1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrPullRequestMerged))
+	assert.Nil(t, pr)
+}
+
+func TestClosePullRequest_ChangedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusOpen}, nil),
+		prRepo.EXPECT().
+			ChangeStatus(gomock.Any(), "pr-1", prdomain.PRStatusOpen, prdomain.PRStatusClosed).
+			Return(false, nil),
+	)
+
+	pr, err := svc.ClosePullRequest(context.Background(), "pr-1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrInvalidStatusTransition))
+	assert.Nil(t, pr)
+}
+
+func TestMergePullRequest_Closed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	gomock.InOrder(
+		prRepo.EXPECT().
+			MarkMerged(gomock.Any(), "pr-1", gomock.Any()).
+			Return(false, nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusClosed}, nil),
+	)
+
+	pr, err := svc.MergePullRequest(context.Background(), "pr-1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrInvalidStatusTransition))
+	assert.Nil(t, pr)
+}
+
+func TestReassignReviewer_OnClosedPR(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusClosed}, nil)
+
+	pr, newID, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2", "", false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrPullRequestNotOpen))
+	assert.Nil(t, pr)
+	assert.Empty(t, newID)
+}
+
+func TestListPullRequests_NextCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	t1 := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(-time.Hour)
+	t3 := t1.Add(-2 * time.Hour)
+
+	prRepo.EXPECT().
+		List(gomock.Any(), prdomain.ListFilter{Status: prdomain.PRStatusOpen, Limit: 3}).
+		Return([]prdomain.PullRequest{
+			{PullRequestID: "pr-3", CreatedAt: &t1},
+			{PullRequestID: "pr-2", CreatedAt: &t2},
+			{PullRequestID: "pr-1", CreatedAt: &t3},
+		}, nil)
+
+	page, err := svc.ListPullRequests(context.Background(), prdomain.ListFilter{Status: prdomain.PRStatusOpen, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 2)
+	require.NotNil(t, page.NextCursor)
+	assert.Equal(t, "pr-2", page.NextCursor.PullRequestID)
+	assert.True(t, t2.Equal(page.NextCursor.CreatedAt))
+}
+
+func TestListPullRequests_LastPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	created := time.Now().UTC()
+	prRepo.EXPECT().
+		List(gomock.Any(), prdomain.ListFilter{Limit: defaultListLimit + 1}).
+		Return([]prdomain.PullRequest{{PullRequestID: "pr-1", CreatedAt: &created}}, nil)
+
+	page, err := svc.ListPullRequests(context.Background(), prdomain.ListFilter{})
+	require.NoError(t, err)
+	assert.Len(t, page.PullRequests, 1)
+	assert.Nil(t, page.NextCursor)
+}
+
+func TestListPullRequests_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	page, err := svc.ListPullRequests(context.Background(), prdomain.ListFilter{Status: "PENDING"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrInvalidFilter))
+	assert.Nil(t, page)
+
+	from := time.Now().UTC()
+	to := from.Add(-time.Hour)
+	page, err = svc.ListPullRequests(context.Background(), prdomain.ListFilter{CreatedFrom: &from, CreatedTo: &to})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrInvalidFilter))
+	assert.Nil(t, page)
+}
+
+func TestCreatePullRequest_HonorsMaxReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "platform", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "platform").
+		Return(&teamdomain.Settings{MinReviewers: 3, MaxReviewers: 3, SelfTeamOnly: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "platform").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "platform", IsActive: true},
+			{UserID: "u3", TeamName: "platform", IsActive: true},
+			{UserID: "u4", TeamName: "platform", IsActive: true},
+			{UserID: "u5", TeamName: "platform", IsActive: false},
+		}, nil)
+	prRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	var assigned []string
+	prRepo.EXPECT().
+		SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, reviewers []string) error {
+			assigned = reviewers
+			return nil
+		})
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1"}, nil)
+
+	_, err := svc.CreatePullRequest(ctx, "pr-1", "Infra change", "u1", false, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3", "u4"}, assigned)
+}
+
+func TestCreatePullRequest_NotEnoughReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "platform", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "platform").
+		Return(&teamdomain.Settings{MinReviewers: 2, MaxReviewers: 3, SelfTeamOnly: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "platform").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "platform", IsActive: true},
+		}, nil)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Infra change", "u1", false, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrNotEnoughReviewers))
+	assert.Nil(t, pr)
+}
+
+func TestCreatePullRequest_TopsUpFromOtherTeams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "mobile", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "mobile").
+		Return(&teamdomain.Settings{MinReviewers: 2, MaxReviewers: 2, SelfTeamOnly: false}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "mobile").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "mobile", IsActive: true},
+		}, nil)
+	teamRepo.EXPECT().
+		List(gomock.Any()).
+		Return([]*teamdomain.Team{
+			{TeamName: "mobile", Members: []teamdomain.TeamMember{
+				{UserID: "u1", IsActive: true},
+				{UserID: "u2", IsActive: true},
+			}},
+			{TeamName: "backend", Members: []teamdomain.TeamMember{
+				{UserID: "u10", IsActive: false},
+				{UserID: "u11", IsActive: true},
+			}},
+		}, nil)
+	prRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	var assigned []string
+	prRepo.EXPECT().
+		SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, reviewers []string) error {
+			assigned = reviewers
+			return nil
+		})
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1"}, nil)
+
+	_, err := svc.CreatePullRequest(ctx, "pr-1", "Mobile fix", "u1", false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u11"}, assigned)
+}
+
+func TestCreatePullRequest_UsesFallbackTeamsInOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "mobile", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "mobile").
+		Return(&teamdomain.Settings{
+			MaxReviewers:  3,
+			FallbackTeams: []string{"frontend", "backend"},
+		}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "mobile").
+		Return([]*userdomain.User{author}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "frontend").
+		Return([]*userdomain.User{
+			{UserID: "u20", TeamName: "frontend", IsActive: true},
+			{UserID: "u21", TeamName: "frontend", IsActive: false},
+		}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{
+			{UserID: "u30", TeamName: "backend", IsActive: true},
+			{UserID: "u31", TeamName: "backend", IsActive: true},
+		}, nil)
+	prRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	var assigned []string
+	prRepo.EXPECT().
+		SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, reviewers []string) error {
+			assigned = reviewers
+			return nil
+		})
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1"}, nil)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Mobile fix", "u1", false, nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u20", "u30", "u31"}, assigned)
+	assert.ElementsMatch(t, []string{"u20", "u30", "u31"}, pr.FallbackReviewers)
+}
+
+func TestReassignReviewer_FallbackTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+	oldRev := &userdomain.User{UserID: "u2", TeamName: "mobile", IsActive: true}
+
+	gomock.InOrder(
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(pr, nil),
+		userRepo.EXPECT().GetByID(gomock.Any(), "u2").Return(oldRev, nil),
+		userRepo.EXPECT().
+			ListByTeam(gomock.Any(), "mobile").
+			Return([]*userdomain.User{oldRev}, nil),
+		teamRepo.EXPECT().
+			GetSettings(gomock.Any(), "mobile").
+			Return(&teamdomain.Settings{MaxReviewers: 2, FallbackTeams: []string{"backend"}}, nil),
+		userRepo.EXPECT().
+			ListByTeam(gomock.Any(), "backend").
+			Return([]*userdomain.User{{UserID: "u30", TeamName: "backend", IsActive: true}}, nil),
+		prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u30"}).Return(nil),
+		prRepo.EXPECT().
+			GetByID(gomock.Any(), "pr-1").
+			Return(&prdomain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u30"}}, nil),
+	)
+
+	updated, newReviewer, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "u30", newReviewer)
+	assert.Equal(t, []string{"u30"}, updated.FallbackReviewers)
+}
+
+func TestCreatePullRequest_PrefersCodeOwners(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	files := []string{"deploy/app.yaml", "internal/app/router.go"}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&teamdomain.Settings{MaxReviewers: 2, SelfTeamOnly: true}, nil)
+	teamRepo.EXPECT().
+		GetCodeOwners(gomock.Any(), "backend").
+		Return([]teamdomain.CodeOwnerRule{
+			{Pattern: "*.go", Owners: []string{"u1"}},
+			{Pattern: "deploy/", Owners: []string{"@infra", "u9"}},
+		}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "infra").
+		Return([]*userdomain.User{
+			{UserID: "u40", TeamName: "infra", IsActive: false},
+			{UserID: "u41", TeamName: "infra", IsActive: true},
+		}, nil)
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u9").
+		Return(nil, userdomain.ErrUserNotFound)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "backend", IsActive: true},
+		}, nil)
+	prRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pr *prdomain.PullRequest) error {
+			assert.Equal(t, files, pr.ChangedFiles)
+			return nil
+		})
+
+	var assigned []string
+	prRepo.EXPECT().
+		SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, reviewers []string) error {
+			assigned = reviewers
+			return nil
+		})
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1"}, nil)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Deploy", "u1", false, files)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u41", "u2"}, assigned)
+	assert.Equal(t, map[string]string{"u41": "deploy/"}, pr.MatchedRules)
+	assert.Empty(t, pr.FallbackReviewers)
+}
+
+func TestMarkReady_UsesStoredChangedFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	draft := &prdomain.PullRequest{
+		PullRequestID: "pr-1",
+		AuthorID:      "u1",
+		Status:        prdomain.PRStatusDraft,
+		ChangedFiles:  []string{"docs/api.md"},
+	}
+	author := &userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+
+	prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(draft, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&teamdomain.Settings{MaxReviewers: 1, SelfTeamOnly: true}, nil)
+	teamRepo.EXPECT().
+		GetCodeOwners(gomock.Any(), "backend").
+		Return([]teamdomain.CodeOwnerRule{{Pattern: "docs/**", Owners: []string{"u5"}}}, nil)
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u5").
+		Return(&userdomain.User{UserID: "u5", TeamName: "docs", IsActive: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{author, {UserID: "u2", TeamName: "backend", IsActive: true}}, nil)
+	prRepo.EXPECT().
+		ChangeStatus(gomock.Any(), "pr-1", prdomain.PRStatusDraft, prdomain.PRStatusOpen).
+		Return(true, nil)
+	prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u5"}).Return(nil)
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusOpen}, nil)
+
+	pr, err := svc.MarkReady(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"u5": "docs/**"}, pr.MatchedRules)
+}
+
+func TestCreatePullRequest_SkipsAbsentReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "platform", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "platform").
+		Return(&teamdomain.Settings{MaxReviewers: 2, SelfTeamOnly: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "platform").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "platform", IsActive: true},
+			{UserID: "u3", TeamName: "platform", IsActive: true},
+		}, nil)
+	userRepo.EXPECT().
+		ListAbsent(gomock.Any(), []string{"u2", "u3"}, gomock.Any()).
+		Return([]string{"u2"}, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u2", "u3"}).Return(nil, nil)
+	prRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u3"}).Return(nil)
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u3"}}, nil)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Infra change", "u1", false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+}
+
+func TestCreatePullRequest_SkipsReviewersAtCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "platform", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "platform").
+		Return(&teamdomain.Settings{MaxReviewers: 2, SelfTeamOnly: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "platform").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "platform", IsActive: true},
+			{UserID: "u3", TeamName: "platform", IsActive: true},
+		}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u2", "u3"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().
+		ListReviewLimits(gomock.Any(), []string{"u2", "u3"}).
+		Return(userdomain.ReviewLimits{"u2": 3, "u3": 3}, nil)
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), []string{"u2", "u3"}).
+		Return(map[string]int64{"u2": 3, "u3": 1}, nil)
+	prRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u3"}).Return(nil)
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u3"}}, nil)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Infra change", "u1", false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	assert.Equal(t, []string{"u2"}, pr.SkippedAtCapacity)
+}
+
+func TestCreatePullRequest_AllReviewersAtCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	author := &userdomain.User{UserID: "u1", TeamName: "platform", IsActive: true}
+
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(author, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "platform").
+		Return(&teamdomain.Settings{MinReviewers: 1, MaxReviewers: 2, SelfTeamOnly: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "platform").
+		Return([]*userdomain.User{
+			author,
+			{UserID: "u2", TeamName: "platform", IsActive: true},
+		}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u2"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().
+		ListReviewLimits(gomock.Any(), []string{"u2"}).
+		Return(userdomain.ReviewLimits{"u2": 1}, nil)
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), []string{"u2"}).
+		Return(map[string]int64{"u2": 1}, nil)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr-1", "Infra change", "u1", false, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrReviewersAtCapacity))
+	assert.Nil(t, pr)
+}
+
+func TestReassignReviewer_AllCandidatesAtCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+	ctx := context.Background()
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u2").
+		Return(&userdomain.User{UserID: "u2", TeamName: "platform", IsActive: true}, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "platform").
+		Return([]*userdomain.User{
+			{UserID: "u2", TeamName: "platform", IsActive: true},
+			{UserID: "u3", TeamName: "platform", IsActive: true},
+		}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u3"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().
+		ListReviewLimits(gomock.Any(), []string{"u3"}).
+		Return(userdomain.ReviewLimits{"u3": 2}, nil)
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), []string{"u3"}).
+		Return(map[string]int64{"u3": 2}, nil)
+
+	pr, newID, err := svc.ReassignReviewer(ctx, "pr-1", "u2", "", false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrReviewersAtCapacity))
+	assert.False(t, errors.Is(err, prdomain.ErrNoCandidate))
+	assert.Nil(t, pr)
+	assert.Empty(t, newID)
+}
+
+func TestReassignReviewer_ToRequestedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	updated := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u9", "u3"},
+	}
+
+	gomock.InOrder(
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(pr, nil),
+		userRepo.EXPECT().
+			GetByID(gomock.Any(), "u9").
+			Return(&userdomain.User{UserID: "u9", TeamName: "frontend", IsActive: true}, nil),
+		prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u9", "u3"}).Return(nil),
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(updated, nil),
+	)
+
+	resPR, newReviewer, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2", "u9", false)
+	require.NoError(t, err)
+	assert.Equal(t, "u9", newReviewer)
+	assert.Equal(t, []string{"u9", "u3"}, resPR.AssignedReviewers)
+}
+
+func TestReassignReviewer_RequestedUserRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		newID   string
+		user    *userdomain.User
+		wantErr error
+	}{
+		{name: "author", newID: "u1", wantErr: prdomain.ErrAuthorCannotReview},
+		{name: "already assigned", newID: "u3", wantErr: prdomain.ErrReviewerAlreadyAssigned},
+		{
+			name:    "inactive",
+			newID:   "u9",
+			user:    &userdomain.User{UserID: "u9", TeamName: "backend", IsActive: false},
+			wantErr: prdomain.ErrReviewerInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+			userRepo := usermocks.NewMockUserRepository(ctrl)
+
+			svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+			prRepo.EXPECT().
+				GetByID(gomock.Any(), "pr-1").
+				Return(&prdomain.PullRequest{
+					PullRequestID:     "pr-1",
+					AuthorID:          "u1",
+					Status:            prdomain.PRStatusOpen,
+					AssignedReviewers: []string{"u2", "u3"},
+				}, nil)
+			if tt.user != nil {
+				userRepo.EXPECT().GetByID(gomock.Any(), tt.newID).Return(tt.user, nil)
+			}
+
+			resPR, _, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2", tt.newID, false)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, tt.wantErr))
+			assert.Nil(t, resPR)
+		})
+	}
+}
+
+func TestAddReviewer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+	updated := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u4"},
+	}
+
+	gomock.InOrder(
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(pr, nil),
+		userRepo.EXPECT().
+			GetByID(gomock.Any(), "u4").
+			Return(&userdomain.User{UserID: "u4", TeamName: "backend", IsActive: true}, nil),
+		prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u2", "u4"}).Return(nil),
+		prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(updated, nil),
+	)
+
+	res, err := svc.AddReviewer(context.Background(), "pr-1", "u4")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u4"}, res.AssignedReviewers)
+}
+
+func TestAddReviewer_NotOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: prdomain.PRStatusDraft}, nil)
+
+	_, err := svc.AddReviewer(context.Background(), "pr-1", "u4")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrPullRequestNotOpen))
+}
+
+func TestRemoveReviewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewPullRequestService(prRepo, userRepo, nil, nil, nil, MergePolicy{}, zap.NewNop())
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+
+	prRepo.EXPECT().GetByID(gomock.Any(), "pr-1").Return(pr, nil).Times(3)
+	prRepo.EXPECT().SetReviewers(gomock.Any(), "pr-1", []string{"u3"}).Return(nil)
+
+	_, err := svc.RemoveReviewer(context.Background(), "pr-1", "u2")
+	require.NoError(t, err)
+
+	_, err = svc.RemoveReviewer(context.Background(), "pr-1", "u9")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrReviewerNotAssigned))
+}
+
+func TestReassignReviewer_SkipsAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&teamdomain.Settings{SelfTeamOnly: true}, nil)
+
+	svc := NewPullRequestService(prRepo, userRepo, teamRepo, nil, nil, MergePolicy{}, zap.NewNop())
+
+	oldRev := &userdomain.User{UserID: "u2", TeamName: "backend", IsActive: true}
+
+	prRepo.EXPECT().
+		GetByID(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "u2").Return(oldRev, nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{{UserID: "u1", TeamName: "backend", IsActive: true}, oldRev}, nil)
+
+	_, _, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2", "", false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, prdomain.ErrNoCandidate))
+}
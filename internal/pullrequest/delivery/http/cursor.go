@@ -0,0 +1,43 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor makes an opaque page token out of the last item's (created_at, pull_request_id).
+func encodeCursor(c *domain.Cursor) string {
+	if c == nil {
+		return ""
+	}
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.PullRequestID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(token string) (*domain.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &domain.Cursor{
+		CreatedAt:     createdAt.UTC(),
+		PullRequestID: id,
+	}, nil
+}
@@ -5,15 +5,39 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
 	"github.com/dunooo0ooo/avito-test-task/pkg/httpcommon"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type PullRequestService interface {
-	CreatePullRequest(ctx context.Context, id string, name string, authorID string) (*domain.PullRequest, error)
+	CreatePullRequest(
+		ctx context.Context,
+		id string,
+		name string,
+		authorID string,
+		draft bool,
+		changedFiles []string,
+	) (*domain.PullRequest, error)
 	MergePullRequest(ctx context.Context, id string) (*domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID string, oldReviewerID string) (*domain.PullRequest, string, error)
+	ReassignReviewer(
+		ctx context.Context,
+		prID string,
+		oldReviewerID string,
+		newReviewerID string,
+		dryRun bool,
+	) (*domain.PullRequest, string, error)
+	AddReviewer(ctx context.Context, prID string, reviewerID string) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, prID string, reviewerID string) (*domain.PullRequest, error)
+	SubmitReview(ctx context.Context, prID string, reviewerID string, decision domain.ReviewDecision) (*domain.PullRequest, error)
+	MarkReady(ctx context.Context, id string) (*domain.PullRequest, error)
+	ClosePullRequest(ctx context.Context, id string) (*domain.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, id string) (*domain.PullRequest, error)
+	GetPullRequest(ctx context.Context, id string) (*domain.PullRequest, error)
+	ListPullRequests(ctx context.Context, filter domain.ListFilter) (*domain.PullRequestPage, error)
 }
 
 type PullRequestHandler struct {
@@ -30,6 +54,14 @@ func (h *PullRequestHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /pullRequest/create", h.Create)
 	mux.HandleFunc("POST /pullRequest/merge", h.Merge)
 	mux.HandleFunc("POST /pullRequest/reassign", h.Reassign)
+	mux.HandleFunc("POST /pullRequest/addReviewer", h.AddReviewer)
+	mux.HandleFunc("POST /pullRequest/removeReviewer", h.RemoveReviewer)
+	mux.HandleFunc("POST /pullRequest/review", h.Review)
+	mux.HandleFunc("POST /pullRequest/ready", h.Ready)
+	mux.HandleFunc("POST /pullRequest/close", h.Close)
+	mux.HandleFunc("POST /pullRequest/reopen", h.Reopen)
+	mux.HandleFunc("GET /pullRequest/get", h.Get)
+	mux.HandleFunc("GET /pullRequest/list", h.List)
 }
 
 func (h *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -40,13 +72,24 @@ func (h *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prs.CreatePullRequest(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := h.prs.CreatePullRequest(
+		r.Context(),
+		req.PullRequestID,
+		req.PullRequestName,
+		req.AuthorID,
+		req.Draft,
+		req.ChangedFiles,
+	)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrPullRequestAlreadyExists):
 			httpcommon.JSONError(w, http.StatusConflict, "PR_EXISTS", "pull request already exists")
-		case errors.Is(err, userdomain.ErrUserNotFound):
+		case errors.Is(err, userdomain.ErrUserNotFound), errors.Is(err, teamdomain.ErrTeamNotFound):
 			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrNotEnoughReviewers):
+			httpcommon.JSONError(w, http.StatusConflict, "NOT_ENOUGH_REVIEWERS", err.Error())
+		case errors.Is(err, domain.ErrReviewersAtCapacity):
+			httpcommon.JSONError(w, http.StatusConflict, "REVIEWERS_AT_CAPACITY", err.Error())
 		default:
 			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
 		}
@@ -60,6 +103,10 @@ func (h *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request) {
 			AuthorID:          pr.AuthorID,
 			Status:            PRStatus(pr.Status),
 			AssignedReviewers: pr.AssignedReviewers,
+			Reviews:           toReviewDTOs(pr.Reviews),
+			FallbackReviewers: pr.FallbackReviewers,
+			MatchedRules:      pr.MatchedRules,
+			SkippedAtCapacity: pr.SkippedAtCapacity,
 		},
 	}
 
@@ -77,10 +124,13 @@ func (h *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request) {
 	pr, err := h.prs.MergePullRequest(r.Context(), req.PullRequestID)
 	if err != nil {
 		switch {
-		case errors.Is(err, userdomain.ErrUserNotFound):
+		case errors.Is(err, domain.ErrPullRequestNotFound),
+			errors.Is(err, userdomain.ErrUserNotFound):
 			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
-		case errors.Is(err, domain.ErrPullRequestMerged):
-			httpcommon.JSONError(w, http.StatusNotFound, "PR_MERGED", "pull request already merged")
+		case errors.Is(err, domain.ErrNotEnoughApprovals):
+			httpcommon.JSONError(w, http.StatusConflict, "NOT_APPROVED", err.Error())
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			httpcommon.JSONError(w, http.StatusConflict, "INVALID_STATUS", err.Error())
 		default:
 			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
 		}
@@ -94,12 +144,14 @@ func (h *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request) {
 			AuthorID:          pr.AuthorID,
 			Status:            PRStatus(pr.Status),
 			AssignedReviewers: pr.AssignedReviewers,
+			Reviews:           toReviewDTOs(pr.Reviews),
 			MergedAt:          pr.MergedAt,
 		},
 	}
 
 	httpcommon.JSONResponse(w, http.StatusOK, resp)
 }
+
 func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 	var req ReassignRequest
 
@@ -108,7 +160,7 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, id, err := h.prs.ReassignReviewer(r.Context(), req.PullRequestID, req.OldReviewerID)
+	pr, id, err := h.prs.ReassignReviewer(r.Context(), req.PullRequestID, req.OldReviewerID, req.NewReviewerID, req.DryRun)
 
 	if err != nil {
 		switch {
@@ -117,10 +169,20 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
 		case errors.Is(err, domain.ErrPullRequestMerged):
 			httpcommon.JSONError(w, http.StatusConflict, "PR_MERGED", "cannot reassign on merged PR")
+		case errors.Is(err, domain.ErrPullRequestNotOpen):
+			httpcommon.JSONError(w, http.StatusConflict, "PR_NOT_OPEN", "pull request is not open")
 		case errors.Is(err, domain.ErrReviewerNotAssigned):
 			httpcommon.JSONError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		case errors.Is(err, domain.ErrReviewerAlreadyAssigned):
+			httpcommon.JSONError(w, http.StatusConflict, "ALREADY_ASSIGNED", "reviewer is already assigned to this PR")
+		case errors.Is(err, domain.ErrAuthorCannotReview):
+			httpcommon.JSONError(w, http.StatusConflict, "AUTHOR_CANNOT_REVIEW", "author cannot review own PR")
+		case errors.Is(err, domain.ErrReviewerInactive):
+			httpcommon.JSONError(w, http.StatusConflict, "REVIEWER_INACTIVE", "reviewer is not active")
 		case errors.Is(err, domain.ErrNoCandidate):
 			httpcommon.JSONError(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
+		case errors.Is(err, domain.ErrReviewersAtCapacity):
+			httpcommon.JSONError(w, http.StatusConflict, "REVIEWERS_AT_CAPACITY", err.Error())
 		default:
 			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
 		}
@@ -134,9 +196,329 @@ func (h *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 			AuthorID:          pr.AuthorID,
 			Status:            PRStatus(pr.Status),
 			AssignedReviewers: pr.AssignedReviewers,
+			Reviews:           toReviewDTOs(pr.Reviews),
+			FallbackReviewers: pr.FallbackReviewers,
+			MatchedRules:      pr.MatchedRules,
+			SkippedAtCapacity: pr.SkippedAtCapacity,
 		},
 		ReplacedReviewer: id,
+		DryRun:           req.DryRun,
 	}
 
 	httpcommon.JSONResponse(w, http.StatusOK, resp)
 }
+
+func (h *PullRequestHandler) Review(w http.ResponseWriter, r *http.Request) {
+	var req ReviewRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := h.prs.SubmitReview(r.Context(), req.PullRequestID, req.ReviewerID, domain.ReviewDecision(req.Decision))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPullRequestNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPullRequestMerged):
+			httpcommon.JSONError(w, http.StatusConflict, "PR_MERGED", "cannot review merged PR")
+		case errors.Is(err, domain.ErrPullRequestNotOpen):
+			httpcommon.JSONError(w, http.StatusConflict, "PR_NOT_OPEN", "pull request is not open")
+		case errors.Is(err, domain.ErrReviewerNotAssigned):
+			httpcommon.JSONError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	resp := ReviewResponse{
+		PullRequestDTO: PullRequestDTO{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			Status:            PRStatus(pr.Status),
+			AssignedReviewers: pr.AssignedReviewers,
+			Reviews:           toReviewDTOs(pr.Reviews),
+			FallbackReviewers: pr.FallbackReviewers,
+			MatchedRules:      pr.MatchedRules,
+			SkippedAtCapacity: pr.SkippedAtCapacity,
+		},
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewers(w, r, h.prs.AddReviewer)
+}
+
+func (h *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewers(w, r, h.prs.RemoveReviewer)
+}
+
+func (h *PullRequestHandler) changeReviewers(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, prID string, reviewerID string) (*domain.PullRequest, error),
+) {
+	var req ReviewerChangeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.PullRequestID == "" || req.UserID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id and user_id are required")
+		return
+	}
+
+	pr, err := change(r.Context(), req.PullRequestID, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPullRequestNotFound),
+			errors.Is(err, userdomain.ErrUserNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPullRequestMerged):
+			httpcommon.JSONError(w, http.StatusConflict, "PR_MERGED", "pull request is already merged")
+		case errors.Is(err, domain.ErrPullRequestNotOpen):
+			httpcommon.JSONError(w, http.StatusConflict, "PR_NOT_OPEN", "pull request is not open")
+		case errors.Is(err, domain.ErrReviewerNotAssigned):
+			httpcommon.JSONError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		case errors.Is(err, domain.ErrReviewerAlreadyAssigned):
+			httpcommon.JSONError(w, http.StatusConflict, "ALREADY_ASSIGNED", "reviewer is already assigned to this PR")
+		case errors.Is(err, domain.ErrAuthorCannotReview):
+			httpcommon.JSONError(w, http.StatusConflict, "AUTHOR_CANNOT_REVIEW", "author cannot review own PR")
+		case errors.Is(err, domain.ErrReviewerInactive):
+			httpcommon.JSONError(w, http.StatusConflict, "REVIEWER_INACTIVE", "reviewer is not active")
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	resp := ReviewerChangeResponse{
+		PullRequestDTO: PullRequestDTO{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			Status:            PRStatus(pr.Status),
+			AssignedReviewers: pr.AssignedReviewers,
+			Reviews:           toReviewDTOs(pr.Reviews),
+		},
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func (h *PullRequestHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prs.MarkReady)
+}
+
+func (h *PullRequestHandler) Close(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prs.ClosePullRequest)
+}
+
+func (h *PullRequestHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prs.ReopenPullRequest)
+}
+
+func (h *PullRequestHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, id string) (*domain.PullRequest, error),
+) {
+	var req StatusChangeRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	pr, err := change(r.Context(), req.PullRequestID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPullRequestNotFound),
+			errors.Is(err, userdomain.ErrUserNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPullRequestMerged):
+			httpcommon.JSONError(w, http.StatusConflict, "PR_MERGED", "pull request is already merged")
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			httpcommon.JSONError(w, http.StatusConflict, "INVALID_STATUS", err.Error())
+		case errors.Is(err, domain.ErrNotEnoughReviewers):
+			httpcommon.JSONError(w, http.StatusConflict, "NOT_ENOUGH_REVIEWERS", err.Error())
+		case errors.Is(err, domain.ErrReviewersAtCapacity):
+			httpcommon.JSONError(w, http.StatusConflict, "REVIEWERS_AT_CAPACITY", err.Error())
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	resp := StatusChangeResponse{
+		PullRequestDTO: PullRequestDTO{
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			Status:            PRStatus(pr.Status),
+			AssignedReviewers: pr.AssignedReviewers,
+			Reviews:           toReviewDTOs(pr.Reviews),
+			FallbackReviewers: pr.FallbackReviewers,
+			MatchedRules:      pr.MatchedRules,
+			SkippedAtCapacity: pr.SkippedAtCapacity,
+		},
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func (h *PullRequestHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pull_request_id")
+	if id == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prs.GetPullRequest(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPullRequestNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	resp := GetResponse{
+		PullRequest: toDetailsDTO(pr),
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func (h *PullRequestHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListFilter(r)
+	if err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	page, err := h.prs.ListPullRequests(r.Context(), filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidFilter):
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		default:
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	resp := ListResponse{
+		PullRequests: make([]PullRequestDetailsDTO, 0, len(page.PullRequests)),
+		NextCursor:   encodeCursor(page.NextCursor),
+	}
+	for i := range page.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, toDetailsDTO(&page.PullRequests[i]))
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func parseListFilter(r *http.Request) (domain.ListFilter, error) {
+	q := r.URL.Query()
+
+	filter := domain.ListFilter{
+		Status:     domain.PRStatus(q.Get("status")),
+		AuthorID:   q.Get("author_id"),
+		ReviewerID: q.Get("reviewer_id"),
+		TeamName:   q.Get("team_name"),
+	}
+
+	if v := q.Get("created_from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("created_from must be RFC3339")
+		}
+		// created_at is stored without a time zone, in UTC.
+		t = t.UTC()
+		filter.CreatedFrom = &t
+	}
+	if v := q.Get("created_to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("created_to must be RFC3339")
+		}
+		t = t.UTC()
+		filter.CreatedTo = &t
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return filter, errors.New("limit must be a positive integer")
+		}
+		filter.Limit = n
+	}
+	if v := q.Get("cursor"); v != "" {
+		c, err := decodeCursor(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = c
+	}
+
+	return filter, nil
+}
+
+func toDetailsDTO(pr *domain.PullRequest) PullRequestDetailsDTO {
+	return PullRequestDetailsDTO{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            PRStatus(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		Reviews:           toReviewDTOs(pr.Reviews),
+		ChangedFiles:      pr.ChangedFiles,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+	}
+}
+
+func toReviewDTOs(reviews []domain.Review) []ReviewDTO {
+	res := make([]ReviewDTO, 0, len(reviews))
+	for _, rv := range reviews {
+		res = append(res, ReviewDTO{
+			ReviewerID:  rv.ReviewerID,
+			Decision:    string(rv.Decision),
+			SubmittedAt: rv.SubmittedAt,
+		})
+	}
+	return res
+}
+
+// ToReviewerChangeDTOs maps reviewer changes made by team operations; the user
+// and roster handlers report them in the same shape.
+func ToReviewerChangeDTOs(changes []domain.ReviewerChange) []ReviewerChangeDTO {
+	res := make([]ReviewerChangeDTO, 0, len(changes))
+
+	for _, c := range changes {
+		dto := ReviewerChangeDTO{
+			PullRequestID: c.PullRequestID,
+			OldReviewers:  append([]string{}, c.OldReviewers...),
+			NewReviewers:  append([]string{}, c.NewReviewers...),
+			Replacements:  make([]ReviewerReplacementDTO, 0, len(c.Replacements)),
+			EmptySlots:    c.EmptySlots(),
+		}
+		for _, rp := range c.Replacements {
+			dto.Replacements = append(dto.Replacements, ReviewerReplacementDTO{
+				OldReviewerID: rp.OldReviewerID,
+				NewReviewerID: rp.NewReviewerID,
+			})
+		}
+		res = append(res, dto)
+	}
+
+	return res
+}
@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
 	prmocks "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/mocks"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -39,7 +41,7 @@ func TestPullRequestHandler_Create_Success(t *testing.T) {
 	}
 
 	svc.EXPECT().
-		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1").
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, nil).
 		Return(pr, nil)
 
 	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
@@ -99,7 +101,7 @@ func TestPullRequestHandler_Create_PRExists(t *testing.T) {
 	h := NewPullRequestHandler(svc)
 
 	svc.EXPECT().
-		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1").
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, nil).
 		Return(nil, prdomain.ErrPullRequestAlreadyExists)
 
 	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
@@ -129,7 +131,7 @@ func TestPullRequestHandler_Create_UserNotFound(t *testing.T) {
 	h := NewPullRequestHandler(svc)
 
 	svc.EXPECT().
-		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1").
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, nil).
 		Return(nil, userdomain.ErrUserNotFound)
 
 	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
@@ -151,6 +153,66 @@ func TestPullRequestHandler_Create_UserNotFound(t *testing.T) {
 	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
 }
 
+func TestPullRequestHandler_Create_AuthorWithoutTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, nil).
+		Return(nil, teamdomain.ErrTeamNotFound)
+
+	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Create_NotEnoughReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, nil).
+		Return(nil, prdomain.ErrNotEnoughReviewers)
+
+	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "NOT_ENOUGH_REVIEWERS", errResp.Error.Code)
+}
+
 func TestPullRequestHandler_Merge_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -246,6 +308,77 @@ func TestPullRequestHandler_Merge_NotFound(t *testing.T) {
 	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
 }
 
+func TestPullRequestHandler_Merge_PRNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		MergePullRequest(gomock.Any(), "pr-1").
+		Return(nil, prdomain.ErrPullRequestNotFound)
+
+	body := `{"pull_request_id":"pr-1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Merge(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Merge_AlreadyMerged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	mergedAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusMerged,
+		AssignedReviewers: []string{"u2"},
+		MergedAt:          &mergedAt,
+	}
+
+	svc.EXPECT().
+		MergePullRequest(gomock.Any(), "pr-1").
+		Return(pr, nil).
+		Times(2)
+
+	for i := 0; i < 2; i++ {
+		body := `{"pull_request_id":"pr-1"}`
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+
+		h.Merge(w, req)
+
+		res := w.Result()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var resp MergeResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+		_ = res.Body.Close()
+
+		require.NotNil(t, resp.MergedPullRequestDTO.MergedAt)
+		assert.True(t, mergedAt.Equal(*resp.MergedPullRequestDTO.MergedAt))
+	}
+}
+
 func TestPullRequestHandler_Reassign_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -262,7 +395,7 @@ func TestPullRequestHandler_Reassign_Success(t *testing.T) {
 	}
 
 	svc.EXPECT().
-		ReassignReviewer(gomock.Any(), "pr-1", "u2").
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", false).
 		Return(pr, "u4", nil)
 
 	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
@@ -285,6 +418,44 @@ func TestPullRequestHandler_Reassign_Success(t *testing.T) {
 	assert.Equal(t, "u4", resp.ReplacedReviewer)
 }
 
+func TestPullRequestHandler_Reassign_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u3", "u4"},
+	}
+
+	svc.EXPECT().
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", true).
+		Return(pr, "u4", nil)
+
+	body := `{"pull_request_id":"pr-1","old_user_id":"u2","dry_run":true}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Reassign(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ReassignResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"u3", "u4"}, resp.PullRequestDTO.AssignedReviewers)
+}
+
 func TestPullRequestHandler_Reassign_InvalidBody(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -318,7 +489,7 @@ func TestPullRequestHandler_Reassign_NotFound(t *testing.T) {
 	h := NewPullRequestHandler(svc)
 
 	svc.EXPECT().
-		ReassignReviewer(gomock.Any(), "pr-1", "u2").
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", false).
 		Return(nil, "", prdomain.ErrPullRequestNotFound)
 
 	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
@@ -348,7 +519,7 @@ func TestPullRequestHandler_Reassign_Merged(t *testing.T) {
 	h := NewPullRequestHandler(svc)
 
 	svc.EXPECT().
-		ReassignReviewer(gomock.Any(), "pr-1", "u2").
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", false).
 		Return(nil, "", prdomain.ErrPullRequestMerged)
 
 	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
@@ -378,7 +549,7 @@ func TestPullRequestHandler_Reassign_NotAssigned(t *testing.T) {
 	h := NewPullRequestHandler(svc)
 
 	svc.EXPECT().
-		ReassignReviewer(gomock.Any(), "pr-1", "u2").
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", false).
 		Return(nil, "", prdomain.ErrReviewerNotAssigned)
 
 	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
@@ -408,7 +579,7 @@ func TestPullRequestHandler_Reassign_NoCandidate(t *testing.T) {
 	h := NewPullRequestHandler(svc)
 
 	svc.EXPECT().
-		ReassignReviewer(gomock.Any(), "pr-1", "u2").
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", false).
 		Return(nil, "", prdomain.ErrNoCandidate)
 
 	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
@@ -429,3 +600,694 @@ func TestPullRequestHandler_Reassign_NoCandidate(t *testing.T) {
 
 	assert.Equal(t, "NO_CANDIDATE", errResp.Error.Code)
 }
+
+func TestPullRequestHandler_Merge_NotApproved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		MergePullRequest(gomock.Any(), "pr-1").
+		Return(nil, prdomain.ErrNotEnoughApprovals)
+
+	body := `{"pull_request_id":"pr-1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Merge(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "NOT_APPROVED", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Review_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	submittedAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews: []prdomain.Review{
+			{ReviewerID: "u2", Decision: prdomain.ReviewApproved, SubmittedAt: submittedAt},
+		},
+	}
+
+	svc.EXPECT().
+		SubmitReview(gomock.Any(), "pr-1", "u2", prdomain.ReviewApproved).
+		Return(pr, nil)
+
+	body := `{"pull_request_id":"pr-1","reviewer_id":"u2","decision":"APPROVED"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Review(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ReviewResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	require.Len(t, resp.PullRequestDTO.Reviews, 1)
+	assert.Equal(t, "u2", resp.PullRequestDTO.Reviews[0].ReviewerID)
+	assert.Equal(t, "APPROVED", resp.PullRequestDTO.Reviews[0].Decision)
+	assert.True(t, submittedAt.Equal(resp.PullRequestDTO.Reviews[0].SubmittedAt))
+}
+
+func TestPullRequestHandler_Review_InvalidBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBufferString("{invalid"))
+	w := httptest.NewRecorder()
+
+	h.Review(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "BAD_REQUEST", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Review_NotAssigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		SubmitReview(gomock.Any(), "pr-1", "u9", prdomain.ReviewCommented).
+		Return(nil, prdomain.ErrReviewerNotAssigned)
+
+	body := `{"pull_request_id":"pr-1","reviewer_id":"u9","decision":"COMMENTED"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/review", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Review(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "NOT_ASSIGNED", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Create_Draft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", true, nil).
+		Return(&prdomain.PullRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Add search",
+			AuthorID:        "u1",
+			Status:          prdomain.PRStatusDraft,
+		}, nil)
+
+	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1","draft":true}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var resp CreateResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, PRStatusDraft, resp.PullRequestDTO.Status)
+	assert.Empty(t, resp.PullRequestDTO.AssignedReviewers)
+}
+
+func TestPullRequestHandler_Close_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		ClosePullRequest(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{PullRequestID: "pr-1", Status: prdomain.PRStatusClosed}, nil)
+
+	body := `{"pull_request_id":"pr-1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/close", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Close(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp StatusChangeResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, PRStatusClosed, resp.PullRequestDTO.Status)
+}
+
+func TestPullRequestHandler_Ready_InvalidStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		MarkReady(gomock.Any(), "pr-1").
+		Return(nil, prdomain.ErrInvalidStatusTransition)
+
+	body := `{"pull_request_id":"pr-1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/ready", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Ready(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "INVALID_STATUS", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Reopen_Merged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		ReopenPullRequest(gomock.Any(), "pr-1").
+		Return(nil, prdomain.ErrPullRequestMerged)
+
+	body := `{"pull_request_id":"pr-1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reopen", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Reopen(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "PR_MERGED", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Get_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	createdAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	svc.EXPECT().
+		GetPullRequest(gomock.Any(), "pr-1").
+		Return(&prdomain.PullRequest{
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add search",
+			AuthorID:          "u1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+			CreatedAt:         &createdAt,
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil)
+	w := httptest.NewRecorder()
+
+	h.Get(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp GetResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, "pr-1", resp.PullRequest.PullRequestID)
+	assert.Equal(t, []string{"u2"}, resp.PullRequest.AssignedReviewers)
+	require.NotNil(t, resp.PullRequest.CreatedAt)
+	assert.True(t, createdAt.Equal(*resp.PullRequest.CreatedAt))
+}
+
+func TestPullRequestHandler_Get_MissingID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get", nil)
+	w := httptest.NewRecorder()
+
+	h.Get(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+func TestPullRequestHandler_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		GetPullRequest(gomock.Any(), "pr-1").
+		Return(nil, prdomain.ErrPullRequestNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", nil)
+	w := httptest.NewRecorder()
+
+	h.Get(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_List_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	createdAt := time.Date(2025, 11, 1, 12, 0, 0, 123456000, time.UTC)
+	next := &prdomain.Cursor{CreatedAt: createdAt, PullRequestID: "pr-2"}
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		svc.EXPECT().
+			ListPullRequests(gomock.Any(), prdomain.ListFilter{
+				Status:      prdomain.PRStatusOpen,
+				TeamName:    "backend",
+				CreatedFrom: &from,
+				Limit:       1,
+			}).
+			Return(&prdomain.PullRequestPage{
+				PullRequests: []prdomain.PullRequest{{PullRequestID: "pr-2", CreatedAt: &createdAt}},
+				NextCursor:   next,
+			}, nil),
+		svc.EXPECT().
+			ListPullRequests(gomock.Any(), prdomain.ListFilter{After: next, Limit: 1}).
+			Return(&prdomain.PullRequestPage{}, nil),
+	)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/pullRequest/list?status=OPEN&team_name=backend&created_from=2025-11-01T00:00:00Z&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	h.List(w, req)
+
+	res := w.Result()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ListResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	_ = res.Body.Close()
+
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, "pr-2", resp.PullRequests[0].PullRequestID)
+	require.NotEmpty(t, resp.NextCursor)
+
+	req = httptest.NewRequest(http.MethodGet, "/pullRequest/list?limit=1&cursor="+resp.NextCursor, nil)
+	w = httptest.NewRecorder()
+
+	h.List(w, req)
+
+	res = w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var last ListResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&last))
+
+	assert.Empty(t, last.PullRequests)
+	assert.Empty(t, last.NextCursor)
+}
+
+func TestPullRequestHandler_List_CreatedRangeInUTC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	from := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 2, 2, 30, 0, 0, time.UTC)
+
+	svc.EXPECT().
+		ListPullRequests(gomock.Any(), prdomain.ListFilter{CreatedFrom: &from, CreatedTo: &to}).
+		Return(&prdomain.PullRequestPage{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/pullRequest/list?created_from=2024-01-01T10:00:00%2B03:00&created_to=2024-01-01T21:00:00-05:30", nil)
+	w := httptest.NewRecorder()
+
+	h.List(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestPullRequestHandler_List_BadParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	for _, query := range []string{
+		"limit=abc",
+		"limit=0",
+		"created_from=yesterday",
+		"cursor=not-a-cursor",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/pullRequest/list?"+query, nil)
+		w := httptest.NewRecorder()
+
+		h.List(w, req)
+
+		res := w.Result()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode, query)
+		_ = res.Body.Close()
+	}
+}
+
+func TestPullRequestHandler_Create_FallbackReviewers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u30"},
+		FallbackReviewers: []string{"u30"},
+	}
+
+	svc.EXPECT().
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, nil).
+		Return(pr, nil)
+
+	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var resp CreateResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, []string{"u2", "u30"}, resp.PullRequestDTO.AssignedReviewers)
+	assert.Equal(t, []string{"u30"}, resp.PullRequestDTO.FallbackReviewers)
+}
+
+func TestPullRequestHandler_Create_ChangedFiles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	pr := &prdomain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            prdomain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u41"},
+		MatchedRules:      map[string]string{"u41": "deploy/"},
+	}
+
+	svc.EXPECT().
+		CreatePullRequest(gomock.Any(), "pr-1", "Add search", "u1", false, []string{"deploy/app.yaml"}).
+		Return(pr, nil)
+
+	body := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1","changed_files":["deploy/app.yaml"]}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Create(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var resp CreateResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, map[string]string{"u41": "deploy/"}, resp.PullRequestDTO.MatchedRules)
+}
+
+func TestPullRequestHandler_Reassign_AtCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "", false).
+		Return(nil, "", prdomain.ErrReviewersAtCapacity)
+
+	body := `{"pull_request_id":"pr-1","old_user_id":"u2"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Reassign(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "REVIEWERS_AT_CAPACITY", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_Reassign_ToRequestedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		ReassignReviewer(gomock.Any(), "pr-1", "u2", "u1", false).
+		Return(nil, "", prdomain.ErrAuthorCannotReview)
+
+	body := `{"pull_request_id":"pr-1","old_user_id":"u2","new_user_id":"u1"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.Reassign(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "AUTHOR_CANNOT_REVIEW", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_AddReviewer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		AddReviewer(gomock.Any(), "pr-1", "u4").
+		Return(&prdomain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2", "u4"},
+		}, nil)
+
+	body := `{"pull_request_id":"pr-1","user_id":"u4"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.AddReviewer(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ReviewerChangeResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, []string{"u2", "u4"}, resp.PullRequestDTO.AssignedReviewers)
+}
+
+func TestPullRequestHandler_AddReviewer_AlreadyAssigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		AddReviewer(gomock.Any(), "pr-1", "u2").
+		Return(nil, prdomain.ErrReviewerAlreadyAssigned)
+
+	body := `{"pull_request_id":"pr-1","user_id":"u2"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/addReviewer", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.AddReviewer(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "ALREADY_ASSIGNED", errResp.Error.Code)
+}
+
+func TestPullRequestHandler_RemoveReviewer_NotAssigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := prmocks.NewMockPullRequestService(ctrl)
+	h := NewPullRequestHandler(svc)
+
+	svc.EXPECT().
+		RemoveReviewer(gomock.Any(), "pr-1", "u9").
+		Return(nil, prdomain.ErrReviewerNotAssigned)
+
+	body := `{"pull_request_id":"pr-1","user_id":"u9"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/removeReviewer", bytes.NewBufferString(body))
+	w := httptest.NewRecorder()
+
+	h.RemoveReviewer(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "NOT_ASSIGNED", errResp.Error.Code)
+}
@@ -1,9 +1,11 @@
 package http
 
 type CreateRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	Draft           bool     `json:"draft"`
+	ChangedFiles    []string `json:"changed_files"`
 }
 
 type MergeRequest struct {
@@ -13,4 +15,21 @@ type MergeRequest struct {
 type ReassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldReviewerID string `json:"old_user_id"`
+	NewReviewerID string `json:"new_user_id"`
+	DryRun        bool   `json:"dry_run"`
+}
+
+type ReviewerChangeRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+}
+
+type ReviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	Decision      string `json:"decision"`
+}
+
+type StatusChangeRequest struct {
+	PullRequestID string `json:"pull_request_id"`
 }
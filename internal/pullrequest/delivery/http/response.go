@@ -7,16 +7,41 @@ import (
 type PRStatus string
 
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
+type ReviewDTO struct {
+	ReviewerID  string    `json:"reviewer_id"`
+	Decision    string    `json:"decision"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
 type PullRequestDTO struct {
-	PullRequestID     string   `json:"pull_request_id"`
-	PullRequestName   string   `json:"pull_request_name"`
-	AuthorID          string   `json:"author_id"`
-	Status            PRStatus `json:"status"`
-	AssignedReviewers []string `json:"assigned_reviewers"`
+	PullRequestID     string            `json:"pull_request_id"`
+	PullRequestName   string            `json:"pull_request_name"`
+	AuthorID          string            `json:"author_id"`
+	Status            PRStatus          `json:"status"`
+	AssignedReviewers []string          `json:"assigned_reviewers"`
+	Reviews           []ReviewDTO       `json:"reviews"`
+	FallbackReviewers []string          `json:"fallback_reviewers,omitempty"`
+	MatchedRules      map[string]string `json:"matched_rules,omitempty"`
+	SkippedAtCapacity []string          `json:"skipped_at_capacity,omitempty"`
+}
+
+type ReviewerReplacementDTO struct {
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id,omitempty"`
+}
+
+type ReviewerChangeDTO struct {
+	PullRequestID string                   `json:"pull_request_id"`
+	OldReviewers  []string                 `json:"old_reviewers"`
+	NewReviewers  []string                 `json:"new_reviewers"`
+	Replacements  []ReviewerReplacementDTO `json:"replacements"`
+	EmptySlots    int                      `json:"empty_slots"`
 }
 
 type CreateResponse struct {
@@ -24,21 +49,57 @@ type CreateResponse struct {
 }
 
 type MergedPullRequestDTO struct {
-	PullRequestID     string     `json:"pull_request_id"`
-	PullRequestName   string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            PRStatus   `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestID     string      `json:"pull_request_id"`
+	PullRequestName   string      `json:"pull_request_name"`
+	AuthorID          string      `json:"author_id"`
+	Status            PRStatus    `json:"status"`
+	AssignedReviewers []string    `json:"assigned_reviewers"`
+	Reviews           []ReviewDTO `json:"reviews"`
+	MergedAt          *time.Time  `json:"mergedAt"`
 }
 
 type MergeResponse struct {
 	MergedPullRequestDTO MergedPullRequestDTO `json:"pr"`
 }
 
+type ReviewResponse struct {
+	PullRequestDTO PullRequestDTO `json:"pr"`
+}
+
+type StatusChangeResponse struct {
+	PullRequestDTO PullRequestDTO `json:"pr"`
+}
+
+type ReviewerChangeResponse struct {
+	PullRequestDTO PullRequestDTO `json:"pr"`
+}
+
+// PullRequestDetailsDTO is returned by get and list.
+type PullRequestDetailsDTO struct {
+	PullRequestID     string      `json:"pull_request_id"`
+	PullRequestName   string      `json:"pull_request_name"`
+	AuthorID          string      `json:"author_id"`
+	Status            PRStatus    `json:"status"`
+	AssignedReviewers []string    `json:"assigned_reviewers"`
+	Reviews           []ReviewDTO `json:"reviews,omitempty"`
+	ChangedFiles      []string    `json:"changed_files,omitempty"`
+	CreatedAt         *time.Time  `json:"createdAt"`
+	MergedAt          *time.Time  `json:"mergedAt"`
+}
+
+type GetResponse struct {
+	PullRequest PullRequestDetailsDTO `json:"pr"`
+}
+
+type ListResponse struct {
+	PullRequests []PullRequestDetailsDTO `json:"pull_requests"`
+	NextCursor   string                  `json:"next_cursor,omitempty"`
+}
+
 type ReassignResponse struct {
 	PullRequestDTO   PullRequestDTO `json:"pr"`
 	ReplacedReviewer string         `json:"replaced_by"`
+	DryRun           bool           `json:"dry_run"`
 }
 
 type ReviewerStatsResponse struct {
@@ -10,6 +10,22 @@ var (
 
 var (
 	ErrReviewerNotAssigned = errors.New("reviewer not assigned")
+
+	ErrReviewerAlreadyAssigned = errors.New("reviewer already assigned")
+	ErrAuthorCannotReview      = errors.New("author cannot review own pull request")
+	ErrReviewerInactive        = errors.New("reviewer is not active")
+	ErrDuplicateReviewer       = errors.New("reviewer assigned twice")
+	ErrTooManyReviewers        = errors.New("too many reviewers")
+
 	ErrPullRequestMerged   = errors.New("pull request merged")
+	ErrPullRequestNotOpen  = errors.New("pull request is not open")
 	ErrNoCandidate         = errors.New("no candidate")
+	ErrNotEnoughReviewers  = errors.New("not enough reviewer candidates")
+	ErrReviewersAtCapacity = errors.New("reviewer candidates are at review capacity")
+	ErrInvalidDecision     = errors.New("invalid review decision")
+	ErrNotEnoughApprovals  = errors.New("not enough approvals")
+	ErrInvalidFilter       = errors.New("invalid list filter")
+
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrUnknownReviewerStrategy = errors.New("unknown reviewer strategy")
 )
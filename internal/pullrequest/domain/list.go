@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// Cursor points at the last pull request of a page in (created_at, pull_request_id) order.
+type Cursor struct {
+	CreatedAt     time.Time
+	PullRequestID string
+}
+
+// ListFilter narrows List results; empty fields are not applied. CreatedFrom is
+// inclusive, CreatedTo is exclusive, TeamName matches the author's team.
+type ListFilter struct {
+	Status      PRStatus
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	After       *Cursor
+	Limit       int
+}
+
+type PullRequestPage struct {
+	PullRequests []PullRequest
+	NextCursor   *Cursor
+}
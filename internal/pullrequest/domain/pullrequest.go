@@ -1,22 +1,109 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type PRStatus string
 
 const (
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// transitions lists allowed status changes: drafts get reviewers once marked ready,
+// closed pull requests can be reopened, MERGED is final.
+var transitions = map[PRStatus][]PRStatus{
+	PRStatusDraft:  {PRStatusOpen, PRStatusClosed},
+	PRStatusOpen:   {PRStatusMerged, PRStatusClosed},
+	PRStatusClosed: {PRStatusOpen},
+}
+
+func (s PRStatus) Valid() bool {
+	switch s {
+	case PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s PRStatus) CanTransitionTo(to PRStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransition reports why the pull request cannot move to the given status.
+func (pr *PullRequest) CheckTransition(to PRStatus) error {
+	if pr.Status.CanTransitionTo(to) {
+		return nil
+	}
+	if pr.Status == PRStatusMerged {
+		return ErrPullRequestMerged
+	}
+	return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, pr.Status, to)
+}
+
+type ReviewDecision string
+
+const (
+	ReviewApproved         ReviewDecision = "APPROVED"
+	ReviewChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewDecision = "COMMENTED"
 )
 
+func (d ReviewDecision) Valid() bool {
+	switch d {
+	case ReviewApproved, ReviewChangesRequested, ReviewCommented:
+		return true
+	default:
+		return false
+	}
+}
+
+// Review is the latest decision submitted by an assigned reviewer.
+type Review struct {
+	ReviewerID  string
+	Decision    ReviewDecision
+	SubmittedAt time.Time
+}
+
 type PullRequest struct {
 	PullRequestID     string
 	PullRequestName   string
 	AuthorID          string
 	Status            PRStatus
 	AssignedReviewers []string
+	Reviews           []Review
+	ChangedFiles      []string
 	CreatedAt         *time.Time
 	MergedAt          *time.Time
+
+	// FallbackReviewers, MatchedRules and SkippedAtCapacity describe how the
+	// operation that returned the pull request picked its reviewers; they are not
+	// stored. FallbackReviewers came from outside the author's team, MatchedRules
+	// maps reviewers chosen as code owners to the pattern that matched and
+	// SkippedAtCapacity lists candidates left out because of their review limit.
+	FallbackReviewers []string
+	MatchedRules      map[string]string
+	SkippedAtCapacity []string
+}
+
+func (pr *PullRequest) Approvals() int {
+	n := 0
+	for _, r := range pr.Reviews {
+		if r.Decision == ReviewApproved {
+			n++
+		}
+	}
+	return n
 }
 
 type PullRequestShort struct {
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPRStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to PRStatus
+		allowed  bool
+	}{
+		{PRStatusDraft, PRStatusOpen, true},
+		{PRStatusDraft, PRStatusClosed, true},
+		{PRStatusDraft, PRStatusMerged, false},
+		{PRStatusOpen, PRStatusMerged, true},
+		{PRStatusOpen, PRStatusClosed, true},
+		{PRStatusOpen, PRStatusDraft, false},
+		{PRStatusClosed, PRStatusOpen, true},
+		{PRStatusClosed, PRStatusMerged, false},
+		{PRStatusMerged, PRStatusOpen, false},
+		{PRStatusMerged, PRStatusClosed, false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.allowed, tc.from.CanTransitionTo(tc.to), "%s -> %s", tc.from, tc.to)
+	}
+}
+
+func TestPullRequest_CheckTransition(t *testing.T) {
+	pr := &PullRequest{Status: PRStatusOpen}
+	assert.NoError(t, pr.CheckTransition(PRStatusClosed))
+
+	pr = &PullRequest{Status: PRStatusMerged}
+	assert.True(t, errors.Is(pr.CheckTransition(PRStatusClosed), ErrPullRequestMerged))
+
+	pr = &PullRequest{Status: PRStatusClosed}
+	assert.True(t, errors.Is(pr.CheckTransition(PRStatusMerged), ErrInvalidStatusTransition))
+}
@@ -0,0 +1,29 @@
+package domain
+
+// ReviewerReplacement moves one review slot of a pull request from OldReviewerID
+// to NewReviewerID. An empty NewReviewerID means nobody could take the slot and
+// the reviewer is only removed.
+type ReviewerReplacement struct {
+	PullRequestID string
+	OldReviewerID string
+	NewReviewerID string
+}
+
+// ReviewerChange is what a bulk reassignment did to one pull request.
+type ReviewerChange struct {
+	PullRequestID string
+	OldReviewers  []string
+	NewReviewers  []string
+	Replacements  []ReviewerReplacement
+}
+
+// EmptySlots returns how many replaced reviewers were not given a successor.
+func (c ReviewerChange) EmptySlots() int {
+	n := 0
+	for _, r := range c.Replacements {
+		if r.NewReviewerID == "" {
+			n++
+		}
+	}
+	return n
+}
@@ -9,8 +9,18 @@ type PullRequestRepository interface {
 	Create(ctx context.Context, pr *PullRequest) error
 	GetByID(ctx context.Context, id string) (*PullRequest, error)
 	UpdateStatus(ctx context.Context, id string, status PRStatus, mergedAt *time.Time) error
+	MarkMerged(ctx context.Context, id string, mergedAt time.Time) (bool, error)
+	ChangeStatus(ctx context.Context, id string, from, to PRStatus) (bool, error)
 	SetReviewers(ctx context.Context, id string, reviewerIDs []string) error
+	SaveReview(ctx context.Context, id string, review Review) error
+	List(ctx context.Context, filter ListFilter) ([]PullRequest, error)
 	ListByReviewer(ctx context.Context, reviewerID string) ([]PullRequestShort, error)
-	ListOpenByReviewers(ctx context.Context, reviewerIDs []string) ([]PullRequestShort, error)
+	// ListOpenWithReviewers loads OPEN pull requests reviewed by any of reviewerIDs
+	// together with all their reviewers, ordered by pull_request_id.
+	ListOpenWithReviewers(ctx context.Context, reviewerIDs []string) ([]PullRequest, error)
+	// ReplaceReviewers applies all replacements at once and drops the decisions
+	// of the removed reviewers.
+	ReplaceReviewers(ctx context.Context, replacements []ReviewerReplacement) error
 	CountByReviewer(ctx context.Context) (map[string]string, error)
+	CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int64, error)
 }
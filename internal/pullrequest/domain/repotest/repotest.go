@@ -0,0 +1,521 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Deps is what the suite needs from a storage backend. SeedUsers must create
+// the team (if missing) and the given active users so pull requests can reference them.
+type Deps struct {
+	PullRequests domain.PullRequestRepository
+	SeedUsers    func(t *testing.T, teamName string, userIDs ...string)
+}
+
+// Run checks that a PullRequestRepository behaves like the postgres one.
+// newDeps is called for every subtest and must return an empty storage.
+func Run(t *testing.T, newDeps func(t *testing.T) Deps) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, d Deps)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateWithChangedFiles", testCreateWithChangedFiles},
+		{"CreateDuplicate", testCreateDuplicate},
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"UpdateStatus", testUpdateStatus},
+		{"MarkMerged", testMarkMerged},
+		{"ChangeStatus", testChangeStatus},
+		{"SetReviewersReplaces", testSetReviewersReplaces},
+		{"SaveReview", testSaveReview},
+		{"SetReviewersDropsStaleReviews", testSetReviewersDropsStaleReviews},
+		{"ListByReviewerOrder", testListByReviewerOrder},
+		{"ListFilters", testListFilters},
+		{"ListKeysetPagination", testListKeysetPagination},
+		{"ListLoadsReviews", testListLoadsReviews},
+		{"ListLoadsChangedFiles", testListLoadsChangedFiles},
+		{"ListOpenWithReviewers", testListOpenWithReviewers},
+		{"ReplaceReviewers", testReplaceReviewers},
+		{"CountByReviewer", testCountByReviewer},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newDeps(t))
+		})
+	}
+}
+
+func createPR(t *testing.T, d Deps, id, authorID string) {
+	t.Helper()
+
+	err := d.PullRequests.Create(context.Background(), &domain.PullRequest{
+		PullRequestID:   id,
+		PullRequestName: "PR " + id,
+		AuthorID:        authorID,
+	})
+	require.NoError(t, err)
+}
+
+func testCreateAndGet(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+
+	createPR(t, d, "pr-1", "u1")
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "pr-1", pr.PullRequestID)
+	assert.Equal(t, "PR pr-1", pr.PullRequestName)
+	assert.Equal(t, "u1", pr.AuthorID)
+	assert.Equal(t, domain.PRStatusOpen, pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+	assert.Empty(t, pr.ChangedFiles)
+	assert.NotNil(t, pr.CreatedAt)
+	assert.Nil(t, pr.MergedAt)
+}
+
+func testCreateWithChangedFiles(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+
+	files := []string{"internal/app/router.go", "deploy/app.yaml"}
+	require.NoError(t, d.PullRequests.Create(ctx, &domain.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "PR pr-1",
+		AuthorID:        "u1",
+		ChangedFiles:    files,
+	}))
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, files, pr.ChangedFiles)
+}
+
+func testCreateDuplicate(t *testing.T, d Deps) {
+	d.SeedUsers(t, "backend", "u1")
+
+	createPR(t, d, "pr-1", "u1")
+
+	err := d.PullRequests.Create(context.Background(), &domain.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "again",
+		AuthorID:        "u1",
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrPullRequestAlreadyExists))
+}
+
+func testGetByIDNotFound(t *testing.T, d Deps) {
+	pr, err := d.PullRequests.GetByID(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrPullRequestNotFound))
+	assert.Nil(t, pr)
+}
+
+func testUpdateStatus(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+	createPR(t, d, "pr-1", "u1")
+
+	mergedAt := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, d.PullRequests.UpdateStatus(ctx, "pr-1", domain.PRStatusMerged, &mergedAt))
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusMerged, pr.Status)
+	require.NotNil(t, pr.MergedAt)
+	assert.True(t, mergedAt.Equal(pr.MergedAt.UTC()))
+
+	err = d.PullRequests.UpdateStatus(ctx, "missing", domain.PRStatusMerged, &mergedAt)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrPullRequestNotFound))
+}
+
+func testMarkMerged(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+	createPR(t, d, "pr-1", "u1")
+
+	first := time.Now().UTC().Truncate(time.Second)
+	merged, err := d.PullRequests.MarkMerged(ctx, "pr-1", first)
+	require.NoError(t, err)
+	assert.True(t, merged)
+
+	merged, err = d.PullRequests.MarkMerged(ctx, "pr-1", first.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, merged)
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.NotNil(t, pr.MergedAt)
+	assert.True(t, first.Equal(pr.MergedAt.UTC()))
+
+	merged, err = d.PullRequests.MarkMerged(ctx, "missing", first)
+	require.NoError(t, err)
+	assert.False(t, merged)
+}
+
+func testChangeStatus(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+
+	err := d.PullRequests.Create(ctx, &domain.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "draft",
+		AuthorID:        "u1",
+		Status:          domain.PRStatusDraft,
+	})
+	require.NoError(t, err)
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusDraft, pr.Status)
+
+	changed, err := d.PullRequests.ChangeStatus(ctx, "pr-1", domain.PRStatusOpen, domain.PRStatusClosed)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = d.PullRequests.ChangeStatus(ctx, "pr-1", domain.PRStatusDraft, domain.PRStatusOpen)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = d.PullRequests.ChangeStatus(ctx, "pr-1", domain.PRStatusOpen, domain.PRStatusClosed)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	pr, err = d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusClosed, pr.Status)
+
+	merged, err := d.PullRequests.MarkMerged(ctx, "pr-1", time.Now().UTC())
+	require.NoError(t, err)
+	assert.False(t, merged)
+
+	changed, err = d.PullRequests.ChangeStatus(ctx, "missing", domain.PRStatusOpen, domain.PRStatusClosed)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func testSetReviewersReplaces(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3", "u4")
+	createPR(t, d, "pr-1", "u1")
+
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u3", "u2"}))
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u4"}))
+
+	pr, err = d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4"}, pr.AssignedReviewers)
+
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", nil))
+
+	pr, err = d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, pr.AssignedReviewers)
+}
+
+func testSaveReview(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3")
+	createPR(t, d, "pr-1", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2", "u3"}))
+
+	at := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+		ReviewerID: "u3", Decision: domain.ReviewChangesRequested, SubmittedAt: at,
+	}))
+	require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+		ReviewerID: "u2", Decision: domain.ReviewCommented, SubmittedAt: at,
+	}))
+	require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+		ReviewerID: "u2", Decision: domain.ReviewApproved, SubmittedAt: at.Add(time.Minute),
+	}))
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, pr.Reviews, 2)
+	assert.Equal(t, "u2", pr.Reviews[0].ReviewerID)
+	assert.Equal(t, domain.ReviewApproved, pr.Reviews[0].Decision)
+	assert.True(t, at.Add(time.Minute).Equal(pr.Reviews[0].SubmittedAt.UTC()))
+	assert.Equal(t, "u3", pr.Reviews[1].ReviewerID)
+	assert.Equal(t, domain.ReviewChangesRequested, pr.Reviews[1].Decision)
+	assert.Equal(t, 1, pr.Approvals())
+}
+
+func testSetReviewersDropsStaleReviews(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3", "u4")
+	createPR(t, d, "pr-1", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2", "u3"}))
+
+	for _, rid := range []string{"u2", "u3"} {
+		require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+			ReviewerID: rid, Decision: domain.ReviewApproved, SubmittedAt: time.Now().UTC(),
+		}))
+	}
+
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2", "u4"}))
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Len(t, pr.Reviews, 1)
+	assert.Equal(t, "u2", pr.Reviews[0].ReviewerID)
+
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", nil))
+
+	pr, err = d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, pr.Reviews)
+}
+
+func testListByReviewerOrder(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2")
+
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		createPR(t, d, id, "u1")
+		require.NoError(t, d.PullRequests.SetReviewers(ctx, id, []string{"u2"}))
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	list, err := d.PullRequests.ListByReviewer(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, "pr-3", list[0].PullRequestID)
+	assert.Equal(t, "pr-2", list[1].PullRequestID)
+	assert.Equal(t, "pr-1", list[2].PullRequestID)
+
+	list, err = d.PullRequests.ListByReviewer(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func ids(prs []domain.PullRequest) []string {
+	res := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		res = append(res, pr.PullRequestID)
+	}
+	return res
+}
+
+func testListFilters(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2")
+	d.SeedUsers(t, "frontend", "u3")
+
+	createPR(t, d, "pr-1", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2"}))
+	time.Sleep(5 * time.Millisecond)
+
+	createPR(t, d, "pr-2", "u3")
+	time.Sleep(5 * time.Millisecond)
+
+	createPR(t, d, "pr-3", "u1")
+	_, err := d.PullRequests.MarkMerged(ctx, "pr-3", time.Now().UTC())
+	require.NoError(t, err)
+
+	all, err := d.PullRequests.List(ctx, domain.ListFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-3", "pr-2", "pr-1"}, ids(all))
+	assert.Equal(t, []string{"u2"}, all[2].AssignedReviewers)
+	assert.NotNil(t, all[0].CreatedAt)
+
+	res, err := d.PullRequests.List(ctx, domain.ListFilter{Status: domain.PRStatusOpen, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-2", "pr-1"}, ids(res))
+
+	res, err = d.PullRequests.List(ctx, domain.ListFilter{AuthorID: "u1", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-3", "pr-1"}, ids(res))
+
+	res, err = d.PullRequests.List(ctx, domain.ListFilter{ReviewerID: "u2", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1"}, ids(res))
+
+	res, err = d.PullRequests.List(ctx, domain.ListFilter{TeamName: "frontend", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-2"}, ids(res))
+
+	from := *all[1].CreatedAt
+	to := *all[0].CreatedAt
+	res, err = d.PullRequests.List(ctx, domain.ListFilter{CreatedFrom: &from, CreatedTo: &to, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-2"}, ids(res))
+}
+
+func testListKeysetPagination(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+
+	for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"} {
+		createPR(t, d, id, "u1")
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	var (
+		got   []string
+		after *domain.Cursor
+	)
+	for {
+		page, err := d.PullRequests.List(ctx, domain.ListFilter{After: after, Limit: 2})
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		got = append(got, ids(page)...)
+		last := page[len(page)-1]
+		after = &domain.Cursor{CreatedAt: *last.CreatedAt, PullRequestID: last.PullRequestID}
+	}
+
+	assert.Equal(t, []string{"pr-5", "pr-4", "pr-3", "pr-2", "pr-1"}, got)
+}
+
+func testListLoadsReviews(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3")
+
+	createPR(t, d, "pr-1", "u1")
+	time.Sleep(5 * time.Millisecond)
+	createPR(t, d, "pr-2", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2", "u3"}))
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-2", []string{"u2"}))
+
+	at := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+		ReviewerID: "u3", Decision: domain.ReviewApproved, SubmittedAt: at,
+	}))
+	require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+		ReviewerID: "u2", Decision: domain.ReviewChangesRequested, SubmittedAt: at,
+	}))
+
+	list, err := d.PullRequests.List(ctx, domain.ListFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr-2", "pr-1"}, ids(list))
+
+	assert.Empty(t, list[0].Reviews)
+	require.Len(t, list[1].Reviews, 2)
+	assert.Equal(t, "u2", list[1].Reviews[0].ReviewerID)
+	assert.Equal(t, domain.ReviewChangesRequested, list[1].Reviews[0].Decision)
+	assert.Equal(t, "u3", list[1].Reviews[1].ReviewerID)
+	assert.Equal(t, domain.ReviewApproved, list[1].Reviews[1].Decision)
+}
+
+func testListLoadsChangedFiles(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1")
+
+	require.NoError(t, d.PullRequests.Create(ctx, &domain.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "PR pr-1",
+		AuthorID:        "u1",
+		ChangedFiles:    []string{"api/handler.go", "README.md"},
+	}))
+	time.Sleep(5 * time.Millisecond)
+	createPR(t, d, "pr-2", "u1")
+
+	list, err := d.PullRequests.List(ctx, domain.ListFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr-2", "pr-1"}, ids(list))
+
+	assert.Nil(t, list[0].ChangedFiles)
+	assert.Equal(t, []string{"api/handler.go", "README.md"}, list[1].ChangedFiles)
+}
+
+func testListOpenWithReviewers(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3", "u4")
+
+	createPR(t, d, "pr-2", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-2", []string{"u4", "u2"}))
+	createPR(t, d, "pr-1", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u3"}))
+	createPR(t, d, "pr-other", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-other", []string{"u4"}))
+	createPR(t, d, "pr-merged", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-merged", []string{"u2"}))
+	_, err := d.PullRequests.MarkMerged(ctx, "pr-merged", time.Now().UTC())
+	require.NoError(t, err)
+
+	list, err := d.PullRequests.ListOpenWithReviewers(ctx, []string{"u2", "u3"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, ids(list))
+	assert.Equal(t, []string{"u3"}, list[0].AssignedReviewers)
+	assert.Equal(t, []string{"u2", "u4"}, list[1].AssignedReviewers)
+	assert.Equal(t, "u1", list[1].AuthorID)
+	assert.Equal(t, domain.PRStatusOpen, list[1].Status)
+
+	list, err = d.PullRequests.ListOpenWithReviewers(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func testReplaceReviewers(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3", "u4", "u5")
+
+	createPR(t, d, "pr-1", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2", "u3"}))
+	createPR(t, d, "pr-2", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-2", []string{"u2"}))
+	require.NoError(t, d.PullRequests.SaveReview(ctx, "pr-1", domain.Review{
+		ReviewerID:  "u2",
+		Decision:    domain.ReviewApproved,
+		SubmittedAt: time.Now().UTC(),
+	}))
+
+	err := d.PullRequests.ReplaceReviewers(ctx, []domain.ReviewerReplacement{
+		{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u4"},
+		{PullRequestID: "pr-1", OldReviewerID: "u3", NewReviewerID: "u5"},
+		{PullRequestID: "pr-2", OldReviewerID: "u2"},
+	})
+	require.NoError(t, err)
+
+	pr, err := d.PullRequests.GetByID(ctx, "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u4", "u5"}, pr.AssignedReviewers)
+	assert.Empty(t, pr.Reviews)
+
+	pr, err = d.PullRequests.GetByID(ctx, "pr-2")
+	require.NoError(t, err)
+	assert.Empty(t, pr.AssignedReviewers)
+
+	require.NoError(t, d.PullRequests.ReplaceReviewers(ctx, nil))
+}
+
+func testCountByReviewer(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedUsers(t, "backend", "u1", "u2", "u3")
+
+	createPR(t, d, "pr-1", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-1", []string{"u2", "u3"}))
+	createPR(t, d, "pr-2", "u1")
+	require.NoError(t, d.PullRequests.SetReviewers(ctx, "pr-2", []string{"u2"}))
+	_, err := d.PullRequests.MarkMerged(ctx, "pr-2", time.Now().UTC())
+	require.NoError(t, err)
+
+	all, err := d.PullRequests.CountByReviewer(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"u2": "2", "u3": "1"}, all)
+
+	open, err := d.PullRequests.CountOpenByReviewers(ctx, []string{"u1", "u2", "u3"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), open["u2"])
+	assert.Equal(t, int64(1), open["u3"])
+	assert.Zero(t, open["u1"])
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxReviewers caps how many reviewers a pull request can have.
+const MaxReviewers = 10
+
+// AssignReviewers replaces the reviewers of the pull request. It is the single
+// place that checks the invariants every mutation path must hold: a merged pull
+// request is frozen, the author never reviews their own pull request, nobody is
+// assigned twice and there are at most MaxReviewers. Decisions of reviewers that
+// are no longer assigned are dropped.
+func (pr *PullRequest) AssignReviewers(ids []string) error {
+	if pr.Status == PRStatusMerged {
+		return ErrPullRequestMerged
+	}
+	if len(ids) > MaxReviewers {
+		return fmt.Errorf("%w: %d > %d", ErrTooManyReviewers, len(ids), MaxReviewers)
+	}
+
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id == pr.AuthorID {
+			return ErrAuthorCannotReview
+		}
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("%w: %s", ErrDuplicateReviewer, id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	reviews := make([]Review, 0, len(pr.Reviews))
+	for _, r := range pr.Reviews {
+		if _, ok := seen[r.ReviewerID]; ok {
+			reviews = append(reviews, r)
+		}
+	}
+
+	pr.AssignedReviewers = slices.Clone(ids)
+	pr.Reviews = reviews
+
+	return nil
+}
+
+// AddReviewer assigns one more reviewer.
+func (pr *PullRequest) AddReviewer(id string) error {
+	if slices.Contains(pr.AssignedReviewers, id) {
+		return ErrReviewerAlreadyAssigned
+	}
+	return pr.AssignReviewers(append(slices.Clone(pr.AssignedReviewers), id))
+}
+
+// RemoveReviewer unassigns a reviewer together with their decision.
+func (pr *PullRequest) RemoveReviewer(id string) error {
+	if !slices.Contains(pr.AssignedReviewers, id) {
+		return ErrReviewerNotAssigned
+	}
+	return pr.AssignReviewers(slices.DeleteFunc(slices.Clone(pr.AssignedReviewers), func(r string) bool {
+		return r == id
+	}))
+}
+
+// ReplaceReviewer puts newID in place of oldID, keeping the order of reviewers.
+func (pr *PullRequest) ReplaceReviewer(oldID, newID string) error {
+	idx := slices.Index(pr.AssignedReviewers, oldID)
+	if idx < 0 {
+		return ErrReviewerNotAssigned
+	}
+	if slices.Contains(pr.AssignedReviewers, newID) {
+		return ErrReviewerAlreadyAssigned
+	}
+
+	ids := slices.Clone(pr.AssignedReviewers)
+	ids[idx] = newID
+
+	return pr.AssignReviewers(ids)
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequest_AssignReviewers(t *testing.T) {
+	pr := &PullRequest{
+		AuthorID: "u1",
+		Status:   PRStatusOpen,
+		Reviews: []Review{
+			{ReviewerID: "u2", Decision: ReviewApproved},
+			{ReviewerID: "u3", Decision: ReviewCommented},
+		},
+	}
+
+	ids := []string{"u3", "u4"}
+	require.NoError(t, pr.AssignReviewers(ids))
+	assert.Equal(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+	require.Len(t, pr.Reviews, 1)
+	assert.Equal(t, "u3", pr.Reviews[0].ReviewerID)
+
+	ids[0] = "u9"
+	assert.Equal(t, "u3", pr.AssignedReviewers[0])
+}
+
+func TestPullRequest_AssignReviewers_NoSelfReview(t *testing.T) {
+	pr := &PullRequest{AuthorID: "u1", Status: PRStatusOpen}
+
+	err := pr.AssignReviewers([]string{"u2", "u1"})
+	assert.True(t, errors.Is(err, ErrAuthorCannotReview))
+	assert.Empty(t, pr.AssignedReviewers)
+}
+
+func TestPullRequest_AssignReviewers_NoDuplicates(t *testing.T) {
+	pr := &PullRequest{AuthorID: "u1", Status: PRStatusOpen}
+
+	err := pr.AssignReviewers([]string{"u2", "u3", "u2"})
+	assert.True(t, errors.Is(err, ErrDuplicateReviewer))
+}
+
+func TestPullRequest_AssignReviewers_MaxReviewers(t *testing.T) {
+	pr := &PullRequest{AuthorID: "u0", Status: PRStatusOpen}
+
+	ids := make([]string, 0, MaxReviewers+1)
+	for i := 1; i <= MaxReviewers; i++ {
+		ids = append(ids, fmt.Sprintf("u%d", i))
+	}
+	require.NoError(t, pr.AssignReviewers(ids))
+
+	err := pr.AssignReviewers(append(ids, "u99"))
+	assert.True(t, errors.Is(err, ErrTooManyReviewers))
+	assert.Len(t, pr.AssignedReviewers, MaxReviewers)
+}
+
+func TestPullRequest_AssignReviewers_FrozenAfterMerge(t *testing.T) {
+	pr := &PullRequest{AuthorID: "u1", Status: PRStatusMerged, AssignedReviewers: []string{"u2"}}
+
+	assert.True(t, errors.Is(pr.AssignReviewers([]string{"u3"}), ErrPullRequestMerged))
+	assert.True(t, errors.Is(pr.AddReviewer("u3"), ErrPullRequestMerged))
+	assert.True(t, errors.Is(pr.RemoveReviewer("u2"), ErrPullRequestMerged))
+	assert.True(t, errors.Is(pr.ReplaceReviewer("u2", "u3"), ErrPullRequestMerged))
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestPullRequest_AddReviewer(t *testing.T) {
+	pr := &PullRequest{AuthorID: "u1", Status: PRStatusOpen, AssignedReviewers: []string{"u2"}}
+
+	require.NoError(t, pr.AddReviewer("u3"))
+	assert.Equal(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	assert.True(t, errors.Is(pr.AddReviewer("u3"), ErrReviewerAlreadyAssigned))
+	assert.True(t, errors.Is(pr.AddReviewer("u1"), ErrAuthorCannotReview))
+}
+
+func TestPullRequest_RemoveReviewer(t *testing.T) {
+	pr := &PullRequest{
+		AuthorID:          "u1",
+		Status:            PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews:           []Review{{ReviewerID: "u2", Decision: ReviewApproved}},
+	}
+
+	require.NoError(t, pr.RemoveReviewer("u2"))
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	assert.Empty(t, pr.Reviews)
+
+	assert.True(t, errors.Is(pr.RemoveReviewer("u2"), ErrReviewerNotAssigned))
+}
+
+func TestPullRequest_ReplaceReviewer(t *testing.T) {
+	pr := &PullRequest{AuthorID: "u1", Status: PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+	require.NoError(t, pr.ReplaceReviewer("u2", "u4"))
+	assert.Equal(t, []string{"u4", "u3"}, pr.AssignedReviewers)
+
+	assert.True(t, errors.Is(pr.ReplaceReviewer("u2", "u5"), ErrReviewerNotAssigned))
+	assert.True(t, errors.Is(pr.ReplaceReviewer("u4", "u3"), ErrReviewerAlreadyAssigned))
+	assert.True(t, errors.Is(pr.ReplaceReviewer("u4", "u1"), ErrAuthorCannotReview))
+	assert.Equal(t, []string{"u4", "u3"}, pr.AssignedReviewers)
+}
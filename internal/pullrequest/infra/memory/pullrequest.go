@@ -0,0 +1,410 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+)
+
+type Repository struct {
+	store *memstore.Store
+}
+
+func NewPullRequestRepository(store *memstore.Store) *Repository {
+	return &Repository{store: store}
+}
+
+func (r *Repository) Create(ctx context.Context, pr *domain.PullRequest) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.PullRequests[pr.PullRequestID]; ok {
+			return fmt.Errorf("%w: %s", domain.ErrPullRequestAlreadyExists, pr.PullRequestID)
+		}
+		if _, ok := t.Users[pr.AuthorID]; !ok {
+			return fmt.Errorf("%w: author %s does not exist", domain.ErrInternalDatabase, pr.AuthorID)
+		}
+
+		status := pr.Status
+		if status == "" {
+			status = domain.PRStatusOpen
+		}
+
+		t.PullRequests[pr.PullRequestID] = memstore.PullRequest{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          string(status),
+			ChangedFiles:    append([]string(nil), pr.ChangedFiles...),
+			CreatedAt:       time.Now().UTC(),
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
+	var (
+		pr    *domain.PullRequest
+		found bool
+	)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		var row memstore.PullRequest
+		row, found = t.PullRequests[id]
+		if found {
+			pr = toDomain(row, t.Reviewers[id], t.Reviews[id])
+		}
+	})
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", domain.ErrPullRequestNotFound, id)
+	}
+
+	return pr, nil
+}
+
+func (r *Repository) UpdateStatus(ctx context.Context, id string, status domain.PRStatus, mergedAt *time.Time) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := t.PullRequests[id]
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrPullRequestNotFound, id)
+		}
+
+		row.Status = string(status)
+		row.MergedAt = mergedAt
+		t.PullRequests[id] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) MarkMerged(ctx context.Context, id string, mergedAt time.Time) (bool, error) {
+	var merged bool
+
+	err := r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := t.PullRequests[id]
+		if !ok || row.Status != string(domain.PRStatusOpen) {
+			return nil
+		}
+
+		row.Status = string(domain.PRStatusMerged)
+		row.MergedAt = &mergedAt
+		t.PullRequests[id] = row
+		merged = true
+
+		return nil
+	})
+
+	return merged, err
+}
+
+func (r *Repository) ChangeStatus(ctx context.Context, id string, from, to domain.PRStatus) (bool, error) {
+	var changed bool
+
+	err := r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := t.PullRequests[id]
+		if !ok || row.Status != string(from) {
+			return nil
+		}
+
+		row.Status = string(to)
+		t.PullRequests[id] = row
+		changed = true
+
+		return nil
+	})
+
+	return changed, err
+}
+
+func (r *Repository) SetReviewers(ctx context.Context, id string, reviewerIDs []string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.PullRequests[id]; !ok {
+			return fmt.Errorf("%w: pull request %s does not exist", domain.ErrInternalDatabase, id)
+		}
+
+		seen := make(map[string]struct{}, len(reviewerIDs))
+		for _, rid := range reviewerIDs {
+			if _, ok := t.Users[rid]; !ok {
+				return fmt.Errorf("%w: reviewer %s does not exist", domain.ErrInternalDatabase, rid)
+			}
+			if _, dup := seen[rid]; dup {
+				return fmt.Errorf("%w: duplicate reviewer %s", domain.ErrInternalDatabase, rid)
+			}
+			seen[rid] = struct{}{}
+		}
+
+		for rid := range t.Reviews[id] {
+			if _, ok := seen[rid]; !ok {
+				delete(t.Reviews[id], rid)
+			}
+		}
+
+		if len(reviewerIDs) == 0 {
+			delete(t.Reviewers, id)
+			return nil
+		}
+
+		t.Reviewers[id] = append([]string(nil), reviewerIDs...)
+
+		return nil
+	})
+}
+
+func (r *Repository) SaveReview(ctx context.Context, id string, review domain.Review) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.PullRequests[id]; !ok {
+			return fmt.Errorf("%w: pull request %s does not exist", domain.ErrInternalDatabase, id)
+		}
+		if _, ok := t.Users[review.ReviewerID]; !ok {
+			return fmt.Errorf("%w: reviewer %s does not exist", domain.ErrInternalDatabase, review.ReviewerID)
+		}
+
+		if t.Reviews[id] == nil {
+			t.Reviews[id] = make(map[string]memstore.Review)
+		}
+		t.Reviews[id][review.ReviewerID] = memstore.Review{
+			ReviewerID:  review.ReviewerID,
+			Decision:    string(review.Decision),
+			SubmittedAt: review.SubmittedAt,
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]domain.PullRequest, error) {
+	var res []domain.PullRequest
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for id, row := range t.PullRequests {
+			if !matches(t, row, filter) {
+				continue
+			}
+			pr := toDomain(row, t.Reviewers[id], t.Reviews[id])
+			res = append(res, *pr)
+		}
+	})
+
+	sort.Slice(res, func(i, j int) bool {
+		return after(res[j], *res[i].CreatedAt, res[i].PullRequestID)
+	})
+
+	if filter.Limit > 0 && len(res) > filter.Limit {
+		res = res[:filter.Limit]
+	}
+
+	return res, nil
+}
+
+func matches(t *memstore.Tables, row memstore.PullRequest, f domain.ListFilter) bool {
+	if f.Status != "" && row.Status != string(f.Status) {
+		return false
+	}
+	if f.AuthorID != "" && row.AuthorID != f.AuthorID {
+		return false
+	}
+	if f.ReviewerID != "" && !slices.Contains(t.Reviewers[row.PullRequestID], f.ReviewerID) {
+		return false
+	}
+	if f.TeamName != "" && t.Users[row.AuthorID].TeamName != f.TeamName {
+		return false
+	}
+	if f.CreatedFrom != nil && row.CreatedAt.Before(*f.CreatedFrom) {
+		return false
+	}
+	if f.CreatedTo != nil && !row.CreatedAt.Before(*f.CreatedTo) {
+		return false
+	}
+	if f.After != nil {
+		pr := domain.PullRequest{PullRequestID: row.PullRequestID, CreatedAt: &row.CreatedAt}
+		if !after(pr, f.After.CreatedAt, f.After.PullRequestID) {
+			return false
+		}
+	}
+	return true
+}
+
+// after reports whether pr goes after the (createdAt, id) key in newest-first order.
+func after(pr domain.PullRequest, createdAt time.Time, id string) bool {
+	if !pr.CreatedAt.Equal(createdAt) {
+		return pr.CreatedAt.Before(createdAt)
+	}
+	return pr.PullRequestID < id
+}
+
+func (r *Repository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	var rows []memstore.PullRequest
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for prID, reviewers := range t.Reviewers {
+			if slices.Contains(reviewers, reviewerID) {
+				rows = append(rows, t.PullRequests[prID])
+			}
+		}
+	})
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].CreatedAt.After(rows[j].CreatedAt)
+	})
+
+	return toShorts(rows), nil
+}
+
+func (r *Repository) ListOpenWithReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequest, error) {
+	if len(reviewerIDs) == 0 {
+		return nil, nil
+	}
+
+	var res []domain.PullRequest
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for prID, reviewers := range t.Reviewers {
+			row := t.PullRequests[prID]
+			if row.Status != string(domain.PRStatusOpen) {
+				continue
+			}
+			for _, rid := range reviewerIDs {
+				if slices.Contains(reviewers, rid) {
+					sorted := append([]string(nil), reviewers...)
+					sort.Strings(sorted)
+					res = append(res, domain.PullRequest{
+						PullRequestID:     row.PullRequestID,
+						PullRequestName:   row.PullRequestName,
+						AuthorID:          row.AuthorID,
+						Status:            domain.PRStatus(row.Status),
+						AssignedReviewers: sorted,
+					})
+					break
+				}
+			}
+		}
+	})
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].PullRequestID < res[j].PullRequestID
+	})
+
+	return res, nil
+}
+
+func (r *Repository) ReplaceReviewers(ctx context.Context, replacements []domain.ReviewerReplacement) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		for _, rp := range replacements {
+			if _, ok := t.PullRequests[rp.PullRequestID]; !ok {
+				return fmt.Errorf("%w: pull request %s does not exist", domain.ErrInternalDatabase, rp.PullRequestID)
+			}
+			if _, ok := t.Users[rp.NewReviewerID]; rp.NewReviewerID != "" && !ok {
+				return fmt.Errorf("%w: reviewer %s does not exist", domain.ErrInternalDatabase, rp.NewReviewerID)
+			}
+
+			reviewers := slices.DeleteFunc(t.Reviewers[rp.PullRequestID], func(id string) bool {
+				return id == rp.OldReviewerID
+			})
+			delete(t.Reviews[rp.PullRequestID], rp.OldReviewerID)
+
+			if rp.NewReviewerID != "" && !slices.Contains(reviewers, rp.NewReviewerID) {
+				reviewers = append(reviewers, rp.NewReviewerID)
+			}
+
+			if len(reviewers) == 0 {
+				delete(t.Reviewers, rp.PullRequestID)
+				continue
+			}
+			t.Reviewers[rp.PullRequestID] = reviewers
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) CountByReviewer(ctx context.Context) (map[string]string, error) {
+	counts := make(map[string]int64)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, reviewers := range t.Reviewers {
+			for _, rid := range reviewers {
+				counts[rid]++
+			}
+		}
+	})
+
+	result := make(map[string]string, len(counts))
+	for rid, cnt := range counts {
+		result[rid] = strconv.FormatInt(cnt, 10)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return result, nil
+	}
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for prID, reviewers := range t.Reviewers {
+			if t.PullRequests[prID].Status != string(domain.PRStatusOpen) {
+				continue
+			}
+			for _, rid := range reviewers {
+				if slices.Contains(reviewerIDs, rid) {
+					result[rid]++
+				}
+			}
+		}
+	})
+
+	return result, nil
+}
+
+func toDomain(row memstore.PullRequest, reviewers []string, reviews map[string]memstore.Review) *domain.PullRequest {
+	sorted := make([]string, len(reviewers))
+	copy(sorted, reviewers)
+	sort.Strings(sorted)
+
+	var decisions []domain.Review
+	for _, rv := range reviews {
+		decisions = append(decisions, domain.Review{
+			ReviewerID:  rv.ReviewerID,
+			Decision:    domain.ReviewDecision(rv.Decision),
+			SubmittedAt: rv.SubmittedAt,
+		})
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		return decisions[i].ReviewerID < decisions[j].ReviewerID
+	})
+
+	createdAt := row.CreatedAt
+
+	return &domain.PullRequest{
+		PullRequestID:     row.PullRequestID,
+		PullRequestName:   row.PullRequestName,
+		AuthorID:          row.AuthorID,
+		Status:            domain.PRStatus(row.Status),
+		AssignedReviewers: sorted,
+		Reviews:           decisions,
+		ChangedFiles:      append([]string(nil), row.ChangedFiles...),
+		CreatedAt:         &createdAt,
+		MergedAt:          row.MergedAt,
+	}
+}
+
+func toShorts(rows []memstore.PullRequest) []domain.PullRequestShort {
+	var res []domain.PullRequestShort
+	for _, row := range rows {
+		res = append(res, domain.PullRequestShort{
+			PullRequestID:   row.PullRequestID,
+			PullRequestName: row.PullRequestName,
+			AuthorID:        row.AuthorID,
+			Status:          domain.PRStatus(row.Status),
+		})
+	}
+	return res
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain/repotest"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teammemory "github.com/dunooo0ooo/avito-test-task/internal/team/infra/memory"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	usermemory "github.com/dunooo0ooo/avito-test-task/internal/user/infra/memory"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Deps {
+		store := memstore.New()
+		teams := teammemory.NewTeamRepository(store)
+		users := usermemory.NewUserRepository(store)
+
+		return repotest.Deps{
+			PullRequests: NewPullRequestRepository(store),
+			SeedUsers: func(t *testing.T, teamName string, userIDs ...string) {
+				ctx := context.Background()
+				if _, err := teams.GetByName(ctx, teamName); err != nil {
+					require.NoError(t, teams.Create(ctx, &teamdomain.Team{TeamName: teamName}))
+				}
+
+				members := make([]userdomain.User, 0, len(userIDs))
+				for _, id := range userIDs {
+					members = append(members, userdomain.User{UserID: id, Username: id, IsActive: true})
+				}
+				require.NoError(t, users.AddTeamMembers(ctx, teamName, members))
+			},
+		}
+	})
+}
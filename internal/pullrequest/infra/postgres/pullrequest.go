@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
 	"time"
 )
 
@@ -19,8 +21,12 @@ func NewPullRequestRepository(pool *pgxpool.Pool) *Repository {
 	return &Repository{pool: pool}
 }
 
+func (r *Repository) conn(ctx context.Context) txmanager.Querier {
+	return txmanager.Conn(ctx, r.pool)
+}
+
 func (r *Repository) Create(ctx context.Context, pr *domain.PullRequest) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -29,8 +35,8 @@ func (r *Repository) Create(ctx context.Context, pr *domain.PullRequest) error {
 	}(tx, ctx)
 
 	const query = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
-		VALUES (@id, @name, @auth, @status)
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, changed_files)
+		VALUES (@id, @name, @auth, @status, @files)
 	`
 
 	status := pr.Status
@@ -38,14 +44,20 @@ func (r *Repository) Create(ctx context.Context, pr *domain.PullRequest) error {
 		status = domain.PRStatusOpen
 	}
 
+	files := pr.ChangedFiles
+	if files == nil {
+		files = []string{}
+	}
+
 	args := pgx.NamedArgs{
 		"id":     pr.PullRequestID,
 		"name":   pr.PullRequestName,
 		"auth":   pr.AuthorID,
 		"status": status,
+		"files":  files,
 	}
 
-	_, err = r.pool.Exec(ctx, query, args)
+	_, err = tx.Exec(ctx, query, args)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -70,6 +82,7 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*domain.PullReques
 			p.status,
 			p.created_at,
 			p.merged_at,
+			p.changed_files,
 			COALESCE(
 				array_agg(rw.reviewer_id ORDER BY rw.reviewer_id)
 					FILTER (WHERE rw.reviewer_id IS NOT NULL),
@@ -85,7 +98,8 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*domain.PullReques
 			p.author_id,
 			p.status,
 			p.created_at,
-			p.merged_at
+			p.merged_at,
+			p.changed_files
 	`
 
 	args := pgx.NamedArgs{"id": id}
@@ -98,13 +112,14 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*domain.PullReques
 		mergedAt  *time.Time
 	)
 
-	err := r.pool.QueryRow(ctx, query, args).Scan(
+	err := r.conn(ctx).QueryRow(ctx, query, args).Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
 		&status,
 		&createdAt,
 		&mergedAt,
+		&pr.ChangedFiles,
 		&reviewers,
 	)
 	if err != nil {
@@ -118,12 +133,59 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*domain.PullReques
 	pr.CreatedAt = createdAt
 	pr.MergedAt = mergedAt
 	pr.AssignedReviewers = reviewers
+	if len(pr.ChangedFiles) == 0 {
+		pr.ChangedFiles = nil
+	}
+
+	reviews, err := r.listReviews(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	pr.Reviews = reviews[id]
 
 	return &pr, nil
 }
 
+// listReviews loads the reviews of the given pull requests in one query, keyed by
+// pull request ID.
+func (r *Repository) listReviews(ctx context.Context, ids []string) (map[string][]domain.Review, error) {
+	const query = `
+		SELECT pull_request_id, reviewer_id, decision, submitted_at
+		FROM pr_reviews
+		WHERE pull_request_id = ANY(@ids)
+		ORDER BY pull_request_id, reviewer_id
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, pgx.NamedArgs{"ids": ids})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	res := make(map[string][]domain.Review)
+
+	for rows.Next() {
+		var (
+			prID     string
+			rv       domain.Review
+			decision string
+		)
+		if err := rows.Scan(&prID, &rv.ReviewerID, &decision, &rv.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		rv.Decision = domain.ReviewDecision(decision)
+		res[prID] = append(res[prID], rv)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return res, nil
+}
+
 func (r *Repository) UpdateStatus(ctx context.Context, id string, status domain.PRStatus, mergedAt *time.Time) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -144,7 +206,7 @@ func (r *Repository) UpdateStatus(ctx context.Context, id string, status domain.
 		"merged_at": mergedAt,
 	}
 
-	cmd, err := r.pool.Exec(ctx, query, args)
+	cmd, err := tx.Exec(ctx, query, args)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -160,8 +222,55 @@ func (r *Repository) UpdateStatus(ctx context.Context, id string, status domain.
 	return nil
 }
 
+// MarkMerged moves an OPEN pull request to MERGED. It reports false when the
+// pull request is missing or was already merged, leaving merged_at untouched.
+func (r *Repository) MarkMerged(ctx context.Context, id string, mergedAt time.Time) (bool, error) {
+	const query = `
+		UPDATE pull_requests
+		SET status    = 'MERGED',
+		    merged_at = @merged_at
+		WHERE pull_request_id = @id
+		  AND status = 'OPEN'
+	`
+
+	args := pgx.NamedArgs{
+		"id":        id,
+		"merged_at": mergedAt,
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
+// ChangeStatus moves the pull request to status `to` only if it is still in `from`.
+func (r *Repository) ChangeStatus(ctx context.Context, id string, from, to domain.PRStatus) (bool, error) {
+	const query = `
+		UPDATE pull_requests
+		SET status = @to
+		WHERE pull_request_id = @id
+		  AND status = @from
+	`
+
+	args := pgx.NamedArgs{
+		"id":   id,
+		"from": string(from),
+		"to":   string(to),
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
 func (r *Repository) SetReviewers(ctx context.Context, id string, reviewerIDs []string) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -178,6 +287,25 @@ func (r *Repository) SetReviewers(ctx context.Context, id string, reviewerIDs []
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
 
+	const deleteReviewsQuery = `
+		DELETE FROM pr_reviews
+		WHERE pull_request_id = @id
+		  AND NOT (reviewer_id = ANY(@reviewer_ids))
+	`
+
+	keep := reviewerIDs
+	if keep == nil {
+		keep = []string{}
+	}
+
+	args := pgx.NamedArgs{
+		"id":           id,
+		"reviewer_ids": keep,
+	}
+	if _, err := tx.Exec(ctx, deleteReviewsQuery, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
 	if len(reviewerIDs) > 0 {
 		const insertQuery = `
 			INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
@@ -202,6 +330,155 @@ func (r *Repository) SetReviewers(ctx context.Context, id string, reviewerIDs []
 	return nil
 }
 
+// SaveReview stores the reviewer's latest decision, replacing the previous one.
+func (r *Repository) SaveReview(ctx context.Context, id string, review domain.Review) error {
+	const query = `
+		INSERT INTO pr_reviews (pull_request_id, reviewer_id, decision, submitted_at)
+		VALUES (@id, @reviewer_id, @decision, @submitted_at)
+		ON CONFLICT (pull_request_id, reviewer_id) DO UPDATE
+		SET decision     = EXCLUDED.decision,
+		    submitted_at = EXCLUDED.submitted_at
+	`
+
+	args := pgx.NamedArgs{
+		"id":           id,
+		"reviewer_id":  review.ReviewerID,
+		"decision":     string(review.Decision),
+		"submitted_at": review.SubmittedAt,
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+// List returns pull requests newest first, ordered by (created_at, pull_request_id),
+// so filter.After can be used for keyset pagination.
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]domain.PullRequest, error) {
+	var where []string
+	args := pgx.NamedArgs{"limit": filter.Limit}
+
+	if filter.Status != "" {
+		where = append(where, "p.status = @status")
+		args["status"] = string(filter.Status)
+	}
+	if filter.AuthorID != "" {
+		where = append(where, "p.author_id = @author_id")
+		args["author_id"] = filter.AuthorID
+	}
+	if filter.ReviewerID != "" {
+		where = append(where, `EXISTS (
+			SELECT 1 FROM pr_reviewers rf
+			WHERE rf.pull_request_id = p.pull_request_id
+			  AND rf.reviewer_id = @reviewer_id
+		)`)
+		args["reviewer_id"] = filter.ReviewerID
+	}
+	if filter.TeamName != "" {
+		where = append(where, `EXISTS (
+			SELECT 1 FROM users ua
+			WHERE ua.user_id = p.author_id
+			  AND ua.team_name = @team_name
+		)`)
+		args["team_name"] = filter.TeamName
+	}
+	if filter.CreatedFrom != nil {
+		where = append(where, "p.created_at >= @created_from")
+		args["created_from"] = *filter.CreatedFrom
+	}
+	if filter.CreatedTo != nil {
+		where = append(where, "p.created_at < @created_to")
+		args["created_to"] = *filter.CreatedTo
+	}
+	if filter.After != nil {
+		where = append(where, "(p.created_at, p.pull_request_id) < (@cursor_created_at::timestamp, @cursor_id::varchar)")
+		args["cursor_created_at"] = filter.After.CreatedAt
+		args["cursor_id"] = filter.After.PullRequestID
+	}
+
+	query := `
+		SELECT
+			p.pull_request_id,
+			p.pull_request_name,
+			p.author_id,
+			p.status,
+			p.created_at,
+			p.merged_at,
+			p.changed_files,
+			ARRAY(
+				SELECT rw.reviewer_id
+				FROM pr_reviewers rw
+				WHERE rw.pull_request_id = p.pull_request_id
+				ORDER BY rw.reviewer_id
+			) AS reviewers
+		FROM pull_requests p
+	`
+	if len(where) > 0 {
+		query += "WHERE " + strings.Join(where, "\n\t\t  AND ")
+	}
+	query += `
+		ORDER BY p.created_at DESC, p.pull_request_id DESC
+		LIMIT @limit
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	var res []domain.PullRequest
+
+	for rows.Next() {
+		var (
+			pr     domain.PullRequest
+			status string
+		)
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&status,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+			&pr.ChangedFiles,
+			&pr.AssignedReviewers,
+		); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		pr.Status = domain.PRStatus(status)
+		if len(pr.ChangedFiles) == 0 {
+			pr.ChangedFiles = nil
+		}
+		res = append(res, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if len(res) == 0 {
+		return res, nil
+	}
+
+	ids := make([]string, 0, len(res))
+	for _, pr := range res {
+		ids = append(ids, pr.PullRequestID)
+	}
+
+	reviews, err := r.listReviews(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].Reviews = reviews[res[i].PullRequestID]
+	}
+
+	return res, nil
+}
+
 func (r *Repository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
 	const query = `
 		SELECT
@@ -218,7 +495,7 @@ func (r *Repository) ListByReviewer(ctx context.Context, reviewerID string) ([]d
 
 	args := pgx.NamedArgs{"rid": reviewerID}
 
-	rows, err := r.pool.Query(ctx, query, args)
+	rows, err := r.conn(ctx).Query(ctx, query, args)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -252,39 +529,46 @@ func (r *Repository) ListByReviewer(ctx context.Context, reviewerID string) ([]d
 	return res, nil
 }
 
-func (r *Repository) ListOpenByReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequestShort, error) {
+func (r *Repository) ListOpenWithReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequest, error) {
 	if len(reviewerIDs) == 0 {
 		return nil, nil
 	}
 
 	const query = `
-		SELECT DISTINCT
+		SELECT
 			p.pull_request_id,
 			p.pull_request_name,
 			p.author_id,
-			p.status
+			p.status,
+			array_agg(rw.reviewer_id ORDER BY rw.reviewer_id) AS reviewers
 		FROM pull_requests p
 		JOIN pr_reviewers rw
 			ON rw.pull_request_id = p.pull_request_id
 		WHERE p.status = 'OPEN'
-		  AND rw.reviewer_id = ANY(@reviewer_ids)
+		  AND p.pull_request_id IN (
+			SELECT pull_request_id
+			FROM pr_reviewers
+			WHERE reviewer_id = ANY(@reviewer_ids)
+		  )
+		GROUP BY
+			p.pull_request_id,
+			p.pull_request_name,
+			p.author_id,
+			p.status
+		ORDER BY p.pull_request_id
 	`
 
-	args := pgx.NamedArgs{
-		"reviewer_ids": reviewerIDs,
-	}
-
-	rows, err := r.pool.Query(ctx, query, args)
+	rows, err := r.conn(ctx).Query(ctx, query, pgx.NamedArgs{"reviewer_ids": reviewerIDs})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
 	defer rows.Close()
 
-	var res []domain.PullRequestShort
+	var res []domain.PullRequest
 
 	for rows.Next() {
 		var (
-			pr     domain.PullRequestShort
+			pr     domain.PullRequest
 			status string
 		)
 
@@ -293,6 +577,7 @@ func (r *Repository) ListOpenByReviewers(ctx context.Context, reviewerIDs []stri
 			&pr.PullRequestName,
 			&pr.AuthorID,
 			&status,
+			&pr.AssignedReviewers,
 		); err != nil {
 			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 		}
@@ -308,6 +593,75 @@ func (r *Repository) ListOpenByReviewers(ctx context.Context, reviewerIDs []stri
 	return res, nil
 }
 
+func (r *Repository) ReplaceReviewers(ctx context.Context, replacements []domain.ReviewerReplacement) error {
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	prIDs := make([]string, 0, len(replacements))
+	oldIDs := make([]string, 0, len(replacements))
+	newIDs := make([]string, 0, len(replacements))
+	for _, rp := range replacements {
+		prIDs = append(prIDs, rp.PullRequestID)
+		oldIDs = append(oldIDs, rp.OldReviewerID)
+		newIDs = append(newIDs, rp.NewReviewerID)
+	}
+
+	args := pgx.NamedArgs{
+		"pr_ids":  prIDs,
+		"old_ids": oldIDs,
+		"new_ids": newIDs,
+	}
+
+	const deleteQuery = `
+		DELETE FROM pr_reviewers rw
+		USING unnest(@pr_ids::text[], @old_ids::text[]) AS x(pull_request_id, reviewer_id)
+		WHERE rw.pull_request_id = x.pull_request_id
+		  AND rw.reviewer_id = x.reviewer_id
+	`
+
+	if _, err := tx.Exec(ctx, deleteQuery, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	const deleteReviewsQuery = `
+		DELETE FROM pr_reviews rv
+		USING unnest(@pr_ids::text[], @old_ids::text[]) AS x(pull_request_id, reviewer_id)
+		WHERE rv.pull_request_id = x.pull_request_id
+		  AND rv.reviewer_id = x.reviewer_id
+	`
+
+	if _, err := tx.Exec(ctx, deleteReviewsQuery, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	const insertQuery = `
+		INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
+		SELECT x.pull_request_id, x.reviewer_id
+		FROM unnest(@pr_ids::text[], @new_ids::text[]) AS x(pull_request_id, reviewer_id)
+		WHERE x.reviewer_id <> ''
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.Exec(ctx, insertQuery, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
 func (r *Repository) CountByReviewer(ctx context.Context) (map[string]string, error) {
 	const query = `
 		SELECT reviewer_id, COUNT(*) AS cnt
@@ -315,7 +669,7 @@ func (r *Repository) CountByReviewer(ctx context.Context) (map[string]string, er
 		GROUP BY reviewer_id
 	`
 
-	rows, err := r.pool.Query(ctx, query)
+	rows, err := r.conn(ctx).Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -340,3 +694,47 @@ func (r *Repository) CountByReviewer(ctx context.Context) (map[string]string, er
 
 	return result, nil
 }
+
+func (r *Repository) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return result, nil
+	}
+
+	const query = `
+		SELECT rw.reviewer_id, COUNT(*) AS cnt
+		FROM pr_reviewers rw
+		JOIN pull_requests p
+			ON p.pull_request_id = rw.pull_request_id
+		WHERE p.status = 'OPEN'
+		  AND rw.reviewer_id = ANY(@reviewer_ids)
+		GROUP BY rw.reviewer_id
+	`
+
+	args := pgx.NamedArgs{
+		"reviewer_ids": reviewerIDs,
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			reviewerID string
+			count      int64
+		)
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		result[reviewerID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain/repotest"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teampg "github.com/dunooo0ooo/avito-test-task/internal/team/infra/postgres"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	userpg "github.com/dunooo0ooo/avito-test-task/internal/user/infra/postgres"
+	"github.com/dunooo0ooo/avito-test-task/pkg/pgtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	pool := pgtest.Pool(t)
+	teams := teampg.NewTeamRepository(pool)
+	users := userpg.NewUserRepository(pool)
+
+	repotest.Run(t, func(t *testing.T) repotest.Deps {
+		pgtest.Reset(t, pool)
+
+		return repotest.Deps{
+			PullRequests: NewPullRequestRepository(pool),
+			SeedUsers: func(t *testing.T, teamName string, userIDs ...string) {
+				ctx := context.Background()
+				if _, err := teams.GetByName(ctx, teamName); err != nil {
+					require.NoError(t, teams.Create(ctx, &teamdomain.Team{TeamName: teamName}))
+				}
+
+				members := make([]userdomain.User, 0, len(userIDs))
+				for _, id := range userIDs {
+					members = append(members, userdomain.User{UserID: id, Username: id, IsActive: true})
+				}
+				require.NoError(t, users.AddTeamMembers(ctx, teamName, members))
+			},
+		}
+	})
+}
@@ -36,6 +36,21 @@ func (m *MockPullRequestRepository) EXPECT() *MockPullRequestRepositoryMockRecor
 	return m.recorder
 }
 
+// ChangeStatus mocks base method.
+func (m *MockPullRequestRepository) ChangeStatus(ctx context.Context, id string, from, to domain.PRStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MockPullRequestRepositoryMockRecorder) ChangeStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MockPullRequestRepository)(nil).ChangeStatus), ctx, id, from, to)
+}
+
 // CountByReviewer mocks base method.
 func (m *MockPullRequestRepository) CountByReviewer(ctx context.Context) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +66,21 @@ func (mr *MockPullRequestRepositoryMockRecorder) CountByReviewer(ctx interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByReviewer", reflect.TypeOf((*MockPullRequestRepository)(nil).CountByReviewer), ctx)
 }
 
+// CountOpenByReviewers mocks base method.
+func (m *MockPullRequestRepository) CountOpenByReviewers(ctx context.Context, reviewerIDs []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenByReviewers", ctx, reviewerIDs)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenByReviewers indicates an expected call of CountOpenByReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) CountOpenByReviewers(ctx, reviewerIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenByReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).CountOpenByReviewers), ctx, reviewerIDs)
+}
+
 // Create mocks base method.
 func (m *MockPullRequestRepository) Create(ctx context.Context, pr *domain.PullRequest) error {
 	m.ctrl.T.Helper()
@@ -80,6 +110,21 @@ func (mr *MockPullRequestRepositoryMockRecorder) GetByID(ctx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPullRequestRepository)(nil).GetByID), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockPullRequestRepository) List(ctx context.Context, filter domain.ListFilter) ([]domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPullRequestRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPullRequestRepository)(nil).List), ctx, filter)
+}
+
 // ListByReviewer mocks base method.
 func (m *MockPullRequestRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
 	m.ctrl.T.Helper()
@@ -95,19 +140,62 @@ func (mr *MockPullRequestRepositoryMockRecorder) ListByReviewer(ctx, reviewerID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByReviewer", reflect.TypeOf((*MockPullRequestRepository)(nil).ListByReviewer), ctx, reviewerID)
 }
 
-// ListOpenByReviewers mocks base method.
-func (m *MockPullRequestRepository) ListOpenByReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequestShort, error) {
+// ListOpenWithReviewers mocks base method.
+func (m *MockPullRequestRepository) ListOpenWithReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOpenByReviewers", ctx, reviewerIDs)
-	ret0, _ := ret[0].([]domain.PullRequestShort)
+	ret := m.ctrl.Call(m, "ListOpenWithReviewers", ctx, reviewerIDs)
+	ret0, _ := ret[0].([]domain.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOpenByReviewers indicates an expected call of ListOpenByReviewers.
-func (mr *MockPullRequestRepositoryMockRecorder) ListOpenByReviewers(ctx, reviewerIDs interface{}) *gomock.Call {
+// ListOpenWithReviewers indicates an expected call of ListOpenWithReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) ListOpenWithReviewers(ctx, reviewerIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenWithReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).ListOpenWithReviewers), ctx, reviewerIDs)
+}
+
+// MarkMerged mocks base method.
+func (m *MockPullRequestRepository) MarkMerged(ctx context.Context, id string, mergedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMerged", ctx, id, mergedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMerged indicates an expected call of MarkMerged.
+func (mr *MockPullRequestRepositoryMockRecorder) MarkMerged(ctx, id, mergedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMerged", reflect.TypeOf((*MockPullRequestRepository)(nil).MarkMerged), ctx, id, mergedAt)
+}
+
+// ReplaceReviewers mocks base method.
+func (m *MockPullRequestRepository) ReplaceReviewers(ctx context.Context, replacements []domain.ReviewerReplacement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceReviewers", ctx, replacements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceReviewers indicates an expected call of ReplaceReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) ReplaceReviewers(ctx, replacements interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).ReplaceReviewers), ctx, replacements)
+}
+
+// SaveReview mocks base method.
+func (m *MockPullRequestRepository) SaveReview(ctx context.Context, id string, review domain.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReview", ctx, id, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReview indicates an expected call of SaveReview.
+func (mr *MockPullRequestRepositoryMockRecorder) SaveReview(ctx, id, review interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenByReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).ListOpenByReviewers), ctx, reviewerIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReview", reflect.TypeOf((*MockPullRequestRepository)(nil).SaveReview), ctx, id, review)
 }
 
 // SetReviewers mocks base method.
@@ -35,19 +35,94 @@ func (m *MockPullRequestService) EXPECT() *MockPullRequestServiceMockRecorder {
 	return m.recorder
 }
 
+// AddReviewer mocks base method.
+func (m *MockPullRequestService) AddReviewer(ctx context.Context, prID, reviewerID string) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReviewer", ctx, prID, reviewerID)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReviewer indicates an expected call of AddReviewer.
+func (mr *MockPullRequestServiceMockRecorder) AddReviewer(ctx, prID, reviewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReviewer", reflect.TypeOf((*MockPullRequestService)(nil).AddReviewer), ctx, prID, reviewerID)
+}
+
+// ClosePullRequest mocks base method.
+func (m *MockPullRequestService) ClosePullRequest(ctx context.Context, id string) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePullRequest", ctx, id)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePullRequest indicates an expected call of ClosePullRequest.
+func (mr *MockPullRequestServiceMockRecorder) ClosePullRequest(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePullRequest", reflect.TypeOf((*MockPullRequestService)(nil).ClosePullRequest), ctx, id)
+}
+
 // CreatePullRequest mocks base method.
-func (m *MockPullRequestService) CreatePullRequest(ctx context.Context, id, name, authorID string) (*domain.PullRequest, error) {
+func (m *MockPullRequestService) CreatePullRequest(ctx context.Context, id, name, authorID string, draft bool, changedFiles []string) (*domain.PullRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePullRequest", ctx, id, name, authorID)
+	ret := m.ctrl.Call(m, "CreatePullRequest", ctx, id, name, authorID, draft, changedFiles)
 	ret0, _ := ret[0].(*domain.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePullRequest indicates an expected call of CreatePullRequest.
-func (mr *MockPullRequestServiceMockRecorder) CreatePullRequest(ctx, id, name, authorID interface{}) *gomock.Call {
+func (mr *MockPullRequestServiceMockRecorder) CreatePullRequest(ctx, id, name, authorID, draft, changedFiles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockPullRequestService)(nil).CreatePullRequest), ctx, id, name, authorID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockPullRequestService)(nil).CreatePullRequest), ctx, id, name, authorID, draft, changedFiles)
+}
+
+// GetPullRequest mocks base method.
+func (m *MockPullRequestService) GetPullRequest(ctx context.Context, id string) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequest", ctx, id)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockPullRequestServiceMockRecorder) GetPullRequest(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockPullRequestService)(nil).GetPullRequest), ctx, id)
+}
+
+// ListPullRequests mocks base method.
+func (m *MockPullRequestService) ListPullRequests(ctx context.Context, filter domain.ListFilter) (*domain.PullRequestPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequests", ctx, filter)
+	ret0, _ := ret[0].(*domain.PullRequestPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequests indicates an expected call of ListPullRequests.
+func (mr *MockPullRequestServiceMockRecorder) ListPullRequests(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequests", reflect.TypeOf((*MockPullRequestService)(nil).ListPullRequests), ctx, filter)
+}
+
+// MarkReady mocks base method.
+func (m *MockPullRequestService) MarkReady(ctx context.Context, id string) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReady", ctx, id)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReady indicates an expected call of MarkReady.
+func (mr *MockPullRequestServiceMockRecorder) MarkReady(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReady", reflect.TypeOf((*MockPullRequestService)(nil).MarkReady), ctx, id)
 }
 
 // MergePullRequest mocks base method.
@@ -66,9 +141,9 @@ func (mr *MockPullRequestServiceMockRecorder) MergePullRequest(ctx, id interface
 }
 
 // ReassignReviewer mocks base method.
-func (m *MockPullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
+func (m *MockPullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string, dryRun bool) (*domain.PullRequest, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldReviewerID)
+	ret := m.ctrl.Call(m, "ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID, dryRun)
 	ret0, _ := ret[0].(*domain.PullRequest)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -76,7 +151,52 @@ func (m *MockPullRequestService) ReassignReviewer(ctx context.Context, prID, old
 }
 
 // ReassignReviewer indicates an expected call of ReassignReviewer.
-func (mr *MockPullRequestServiceMockRecorder) ReassignReviewer(ctx, prID, oldReviewerID interface{}) *gomock.Call {
+func (mr *MockPullRequestServiceMockRecorder) ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPullRequestService)(nil).ReassignReviewer), ctx, prID, oldReviewerID, newReviewerID, dryRun)
+}
+
+// RemoveReviewer mocks base method.
+func (m *MockPullRequestService) RemoveReviewer(ctx context.Context, prID, reviewerID string) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReviewer", ctx, prID, reviewerID)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReviewer indicates an expected call of RemoveReviewer.
+func (mr *MockPullRequestServiceMockRecorder) RemoveReviewer(ctx, prID, reviewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReviewer", reflect.TypeOf((*MockPullRequestService)(nil).RemoveReviewer), ctx, prID, reviewerID)
+}
+
+// ReopenPullRequest mocks base method.
+func (m *MockPullRequestService) ReopenPullRequest(ctx context.Context, id string) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReopenPullRequest", ctx, id)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReopenPullRequest indicates an expected call of ReopenPullRequest.
+func (mr *MockPullRequestServiceMockRecorder) ReopenPullRequest(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReopenPullRequest", reflect.TypeOf((*MockPullRequestService)(nil).ReopenPullRequest), ctx, id)
+}
+
+// SubmitReview mocks base method.
+func (m *MockPullRequestService) SubmitReview(ctx context.Context, prID, reviewerID string, decision domain.ReviewDecision) (*domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitReview", ctx, prID, reviewerID, decision)
+	ret0, _ := ret[0].(*domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitReview indicates an expected call of SubmitReview.
+func (mr *MockPullRequestServiceMockRecorder) SubmitReview(ctx, prID, reviewerID, decision interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignReviewer", reflect.TypeOf((*MockPullRequestService)(nil).ReassignReviewer), ctx, prID, oldReviewerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitReview", reflect.TypeOf((*MockPullRequestService)(nil).SubmitReview), ctx, prID, reviewerID, decision)
 }
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/roster/domain"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
+)
+
+// UserService is the part of the user service the sync relies on to move and
+// deactivate users together with their reviews.
+type UserService interface {
+	MoveTeam(ctx context.Context, userID, toTeam string, reassign bool) (*userdomain.TeamMoveResult, error)
+	DeactivateTeamUsersAndReassign(
+		ctx context.Context,
+		teamName string,
+		userIDs []string,
+		dryRun bool,
+	) (*userdomain.DeactivationReport, error)
+}
+
+type SyncService struct {
+	teams   teamdomain.TeamRepository
+	users   userdomain.UserRepository
+	userSvc UserService
+	tx      txmanager.TxManager
+	logger  *zap.Logger
+}
+
+func NewSyncService(
+	teams teamdomain.TeamRepository,
+	users userdomain.UserRepository,
+	userSvc UserService,
+	tx txmanager.TxManager,
+	logger *zap.Logger,
+) *SyncService {
+	if tx == nil {
+		tx = txmanager.Nop{}
+	}
+
+	return &SyncService{
+		teams:   teams,
+		users:   users,
+		userSvc: userSvc,
+		tx:      tx,
+		logger:  logger,
+	}
+}
+
+// Sync brings teams and users in line with the roster in one transaction:
+// missing teams and users are created, users change team and username, and
+// those the roster marks inactive or does not list are deactivated. Moved and
+// deactivated users hand their open reviews over the same way the user API
+// does. With dryRun only the plan is computed.
+func (s *SyncService) Sync(ctx context.Context, r domain.Roster, dryRun bool) (*domain.SyncResult, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res *domain.SyncResult
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.sync(ctx, r, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *SyncService) sync(ctx context.Context, r domain.Roster, dryRun bool) (*domain.SyncResult, error) {
+	current, err := s.teams.List(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list teams for roster sync", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	teamless, err := s.teamless(ctx, current, r)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.SyncResult{
+		DryRun: dryRun,
+		Plan:   domain.Diff(current, teamless, r),
+	}
+
+	if dryRun || res.Plan.Empty() {
+		return res, nil
+	}
+
+	if err := s.apply(ctx, res); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to apply roster", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("roster applied",
+			zap.Int("teams_created", len(res.Plan.CreateTeams)),
+			zap.Int("users_created", len(res.Plan.CreateUsers)),
+			zap.Int("users_moved", len(res.Plan.MoveUsers)),
+			zap.Int("users_renamed", len(res.Plan.RenameUsers)),
+			zap.Int("users_activated", len(res.Plan.ActivateUsers)),
+			zap.Int("teams_with_deactivations", len(res.Plan.DeactivateUsers)),
+			zap.Int("pull_requests_changed", len(res.PullRequests)),
+		)
+	}
+
+	return res, nil
+}
+
+// teamless looks up the roster members missing from every team: those that
+// exist have been removed from their team and only need to join one.
+func (s *SyncService) teamless(ctx context.Context, current []*teamdomain.Team, r domain.Roster) ([]userdomain.User, error) {
+	inTeam := make(map[string]struct{})
+	for _, t := range current {
+		for _, m := range t.Members {
+			inTeam[m.UserID] = struct{}{}
+		}
+	}
+
+	var res []userdomain.User
+	for _, t := range r.Teams {
+		for _, m := range t.Members {
+			if _, ok := inTeam[m.UserID]; ok {
+				continue
+			}
+
+			u, err := s.users.GetByID(ctx, m.UserID)
+			switch {
+			case errors.Is(err, userdomain.ErrUserNotFound):
+				continue
+			case err != nil:
+				return nil, err
+			}
+			res = append(res, *u)
+		}
+	}
+
+	return res, nil
+}
+
+// apply runs the plan in an order where every step finds what it needs:
+// teams exist before users join them, and deactivations come last so reviews
+// are only handed to users who stay active.
+func (s *SyncService) apply(ctx context.Context, res *domain.SyncResult) error {
+	plan := res.Plan
+
+	for _, name := range plan.CreateTeams {
+		if err := s.teams.Create(ctx, &teamdomain.Team{TeamName: name}); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range plan.CreateUsers {
+		if err := s.users.Create(ctx, u); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range plan.ActivateUsers {
+		if err := s.users.UpdateActive(ctx, id, true); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range plan.RenameUsers {
+		if err := s.users.UpdateUsername(ctx, c.UserID, c.To); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range plan.MoveUsers {
+		moved, err := s.userSvc.MoveTeam(ctx, m.UserID, m.ToTeam, true)
+		if err != nil {
+			return err
+		}
+		res.PullRequests = append(res.PullRequests, moved.PullRequests...)
+	}
+
+	for _, d := range plan.DeactivateUsers {
+		report, err := s.userSvc.DeactivateTeamUsersAndReassign(ctx, d.TeamName, d.UserIDs, false)
+		if err != nil {
+			return err
+		}
+		res.PullRequests = append(res.PullRequests, report.PullRequests...)
+	}
+
+	return nil
+}
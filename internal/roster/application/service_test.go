@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	"github.com/dunooo0ooo/avito-test-task/internal/roster/domain"
+	rostermocks "github.com/dunooo0ooo/avito-test-task/internal/roster/mocks"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teammocks "github.com/dunooo0ooo/avito-test-task/internal/team/mocks"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	usermocks "github.com/dunooo0ooo/avito-test-task/internal/user/mocks"
+)
+
+func syncRoster() domain.Roster {
+	return domain.Roster{Teams: []domain.Team{
+		{TeamName: "backend", Members: []domain.Member{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		}},
+		{TeamName: "platform", Members: []domain.Member{
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		}},
+	}}
+}
+
+func currentTeams() []*teamdomain.Team {
+	return []*teamdomain.Team{
+		{TeamName: "backend", Members: []teamdomain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		}},
+	}
+}
+
+func TestSyncService_Sync_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userSvc := rostermocks.NewMockUserService(ctrl)
+	svc := NewSyncService(teamRepo, userRepo, userSvc, nil, zap.NewNop())
+
+	teamRepo.EXPECT().List(gomock.Any()).Return(currentTeams(), nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "u3").Return(nil, userdomain.ErrUserNotFound)
+
+	res, err := svc.Sync(context.Background(), syncRoster(), true)
+	require.NoError(t, err)
+
+	assert.True(t, res.DryRun)
+	assert.Equal(t, []string{"platform"}, res.Plan.CreateTeams)
+	assert.Equal(t, []userdomain.User{{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true}}, res.Plan.CreateUsers)
+	assert.Equal(t, []domain.UserMove{{UserID: "u2", FromTeam: "backend", ToTeam: "platform"}}, res.Plan.MoveUsers)
+	assert.Equal(t, []domain.TeamDeactivation{{TeamName: "backend", UserIDs: []string{"u4"}}}, res.Plan.DeactivateUsers)
+	assert.Empty(t, res.PullRequests)
+}
+
+func TestSyncService_Sync_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userSvc := rostermocks.NewMockUserService(ctrl)
+	svc := NewSyncService(teamRepo, userRepo, userSvc, nil, zap.NewNop())
+
+	moveChange := prdomain.ReviewerChange{PullRequestID: "pr-1", OldReviewers: []string{"u2"}, NewReviewers: []string{"u1"}}
+	deactivateChange := prdomain.ReviewerChange{PullRequestID: "pr-2", OldReviewers: []string{"u4"}, NewReviewers: []string{"u3"}}
+
+	gomock.InOrder(
+		teamRepo.EXPECT().List(gomock.Any()).Return(currentTeams(), nil),
+		userRepo.EXPECT().GetByID(gomock.Any(), "u3").Return(nil, userdomain.ErrUserNotFound),
+		teamRepo.EXPECT().Create(gomock.Any(), &teamdomain.Team{TeamName: "platform"}).Return(nil),
+		userRepo.EXPECT().
+			Create(gomock.Any(), userdomain.User{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true}).
+			Return(nil),
+		userSvc.EXPECT().
+			MoveTeam(gomock.Any(), "u2", "platform", true).
+			Return(&userdomain.TeamMoveResult{PullRequests: []prdomain.ReviewerChange{moveChange}}, nil),
+		userSvc.EXPECT().
+			DeactivateTeamUsersAndReassign(gomock.Any(), "backend", []string{"u4"}, false).
+			Return(&userdomain.DeactivationReport{PullRequests: []prdomain.ReviewerChange{deactivateChange}}, nil),
+	)
+
+	res, err := svc.Sync(context.Background(), syncRoster(), false)
+	require.NoError(t, err)
+
+	assert.False(t, res.DryRun)
+	assert.Equal(t, []prdomain.ReviewerChange{moveChange, deactivateChange}, res.PullRequests)
+}
+
+func TestSyncService_Sync_RenameAndActivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userSvc := rostermocks.NewMockUserService(ctrl)
+	svc := NewSyncService(teamRepo, userRepo, userSvc, nil, zap.NewNop())
+
+	teamRepo.EXPECT().List(gomock.Any()).Return([]*teamdomain.Team{
+		{TeamName: "backend", Members: []teamdomain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: false},
+		}},
+	}, nil)
+	userRepo.EXPECT().UpdateActive(gomock.Any(), "u1", true).Return(nil)
+	userRepo.EXPECT().UpdateUsername(gomock.Any(), "u1", "Alicia").Return(nil)
+
+	r := domain.Roster{Teams: []domain.Team{
+		{TeamName: "backend", Members: []domain.Member{{UserID: "u1", Username: "Alicia", IsActive: true}}},
+	}}
+
+	res, err := svc.Sync(context.Background(), r, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"u1"}, res.Plan.ActivateUsers)
+	assert.Equal(t, []domain.UsernameChange{{UserID: "u1", From: "Alice", To: "Alicia"}}, res.Plan.RenameUsers)
+}
+
+func TestSyncService_Sync_TeamlessUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userSvc := rostermocks.NewMockUserService(ctrl)
+	svc := NewSyncService(teamRepo, userRepo, userSvc, nil, zap.NewNop())
+
+	teamRepo.EXPECT().List(gomock.Any()).Return([]*teamdomain.Team{{TeamName: "backend"}}, nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(&userdomain.User{UserID: "u1", Username: "Alice", IsActive: true}, nil)
+	userSvc.EXPECT().MoveTeam(gomock.Any(), "u1", "backend", true).Return(&userdomain.TeamMoveResult{}, nil)
+
+	r := domain.Roster{Teams: []domain.Team{
+		{TeamName: "backend", Members: []domain.Member{{UserID: "u1", Username: "Alice", IsActive: true}}},
+	}}
+
+	res, err := svc.Sync(context.Background(), r, false)
+	require.NoError(t, err)
+
+	assert.Empty(t, res.Plan.CreateUsers)
+	assert.Equal(t, []domain.UserMove{{UserID: "u1", FromTeam: "", ToTeam: "backend"}}, res.Plan.MoveUsers)
+}
+
+func TestSyncService_Sync_InvalidRoster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userSvc := rostermocks.NewMockUserService(ctrl)
+	svc := NewSyncService(teamRepo, userRepo, userSvc, nil, zap.NewNop())
+
+	r := domain.Roster{Teams: []domain.Team{{TeamName: "backend"}, {TeamName: "backend"}}}
+
+	_, err := svc.Sync(context.Background(), r, false)
+	require.ErrorIs(t, err, domain.ErrInvalidRoster)
+}
+
+func TestSyncService_Sync_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	userSvc := rostermocks.NewMockUserService(ctrl)
+	svc := NewSyncService(teamRepo, userRepo, userSvc, nil, zap.NewNop())
+
+	expectedErr := errors.New("db error")
+
+	teamRepo.EXPECT().List(gomock.Any()).Return(currentTeams(), nil)
+	userRepo.EXPECT().GetByID(gomock.Any(), "u3").Return(nil, userdomain.ErrUserNotFound)
+	teamRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	userSvc.EXPECT().MoveTeam(gomock.Any(), "u2", "platform", true).Return(nil, expectedErr)
+
+	_, err := svc.Sync(context.Background(), syncRoster(), false)
+	require.ErrorIs(t, err, expectedErr)
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	prhttp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
+	"github.com/dunooo0ooo/avito-test-task/internal/roster/domain"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/httpcommon"
+)
+
+type SyncService interface {
+	Sync(ctx context.Context, r domain.Roster, dryRun bool) (*domain.SyncResult, error)
+}
+
+type RosterHandler struct {
+	svc SyncService
+}
+
+func NewRosterHandler(svc SyncService) *RosterHandler {
+	return &RosterHandler{svc: svc}
+}
+
+func (h *RosterHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /team/sync", h.Sync)
+}
+
+func (h *RosterHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	var req SyncRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	roster := domain.Roster{Teams: make([]domain.Team, 0, len(req.Teams))}
+	for _, t := range req.Teams {
+		team := domain.Team{TeamName: t.TeamName, Members: make([]domain.Member, 0, len(t.Members))}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, domain.NewMember(m.UserID, m.Username, m.IsActive))
+		}
+		roster.Teams = append(roster.Teams, team)
+	}
+
+	res, err := h.svc.Sync(r.Context(), roster, req.DryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRoster):
+			httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_ROSTER", err.Error())
+		case errors.Is(err, teamdomain.ErrTeamAlreadyExists), errors.Is(err, userdomain.ErrUserExists):
+			// Someone changed teams or users while the roster was being applied.
+			httpcommon.JSONError(w, http.StatusConflict, "CONFLICT", err.Error())
+		default:
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, SyncResponse{
+		DryRun:       res.DryRun,
+		Plan:         toPlanDTO(res.Plan),
+		PullRequests: prhttp.ToReviewerChangeDTOs(res.PullRequests),
+	})
+}
+
+func toPlanDTO(p domain.Plan) PlanDTO {
+	dto := PlanDTO{
+		CreateTeams:     append([]string{}, p.CreateTeams...),
+		CreateUsers:     make([]UserDTO, 0, len(p.CreateUsers)),
+		MoveUsers:       make([]UserMoveDTO, 0, len(p.MoveUsers)),
+		RenameUsers:     make([]UsernameChangeDTO, 0, len(p.RenameUsers)),
+		ActivateUsers:   append([]string{}, p.ActivateUsers...),
+		DeactivateUsers: make([]TeamDeactivationDTO, 0, len(p.DeactivateUsers)),
+	}
+
+	for _, u := range p.CreateUsers {
+		dto.CreateUsers = append(dto.CreateUsers, UserDTO{
+			UserID:   u.UserID,
+			Username: u.Username,
+			TeamName: u.TeamName,
+			IsActive: u.IsActive,
+		})
+	}
+	for _, m := range p.MoveUsers {
+		dto.MoveUsers = append(dto.MoveUsers, UserMoveDTO{
+			UserID:   m.UserID,
+			FromTeam: m.FromTeam,
+			ToTeam:   m.ToTeam,
+		})
+	}
+	for _, c := range p.RenameUsers {
+		dto.RenameUsers = append(dto.RenameUsers, UsernameChangeDTO{
+			UserID: c.UserID,
+			From:   c.From,
+			To:     c.To,
+		})
+	}
+	for _, d := range p.DeactivateUsers {
+		dto.DeactivateUsers = append(dto.DeactivateUsers, TeamDeactivationDTO{
+			TeamName: d.TeamName,
+			UserIDs:  append([]string{}, d.UserIDs...),
+		})
+	}
+
+	return dto
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	"github.com/dunooo0ooo/avito-test-task/internal/roster/domain"
+	rostermocks "github.com/dunooo0ooo/avito-test-task/internal/roster/mocks"
+)
+
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func TestRosterHandler_Sync_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := rostermocks.NewMockSyncService(ctrl)
+	h := NewRosterHandler(svc)
+
+	roster := domain.Roster{Teams: []domain.Team{
+		{TeamName: "backend", Members: []domain.Member{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		}},
+	}}
+
+	svc.EXPECT().
+		Sync(gomock.Any(), roster, false).
+		Return(&domain.SyncResult{
+			Plan: domain.Plan{
+				MoveUsers:       []domain.UserMove{{UserID: "u1", FromTeam: "frontend", ToTeam: "backend"}},
+				DeactivateUsers: []domain.TeamDeactivation{{TeamName: "backend", UserIDs: []string{"u2"}}},
+			},
+			PullRequests: []prdomain.ReviewerChange{{
+				PullRequestID: "pr-1",
+				OldReviewers:  []string{"u2"},
+				NewReviewers:  []string{"u3"},
+				Replacements:  []prdomain.ReviewerReplacement{{OldReviewerID: "u2", NewReviewerID: "u3"}},
+			}},
+		}, nil)
+
+	body := `{"teams":[{"team_name":"backend","members":[` +
+		`{"user_id":"u1","username":"Alice"},` +
+		`{"user_id":"u2","username":"Bob","is_active":false}]}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Sync(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp SyncResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.False(t, resp.DryRun)
+	assert.Empty(t, resp.Plan.CreateTeams)
+	assert.Equal(t, []UserMoveDTO{{UserID: "u1", FromTeam: "frontend", ToTeam: "backend"}}, resp.Plan.MoveUsers)
+	assert.Equal(t, []TeamDeactivationDTO{{TeamName: "backend", UserIDs: []string{"u2"}}}, resp.Plan.DeactivateUsers)
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, "pr-1", resp.PullRequests[0].PullRequestID)
+	assert.Equal(t, []string{"u3"}, resp.PullRequests[0].NewReviewers)
+}
+
+func TestRosterHandler_Sync_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := rostermocks.NewMockSyncService(ctrl)
+	h := NewRosterHandler(svc)
+
+	svc.EXPECT().
+		Sync(gomock.Any(), gomock.Any(), true).
+		Return(&domain.SyncResult{DryRun: true, Plan: domain.Plan{CreateTeams: []string{"backend"}}}, nil)
+
+	body := `{"teams":[{"team_name":"backend"}],"dry_run":true}`
+	req := httptest.NewRequest(http.MethodPost, "/team/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Sync(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp SyncResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.True(t, resp.DryRun)
+	assert.Equal(t, []string{"backend"}, resp.Plan.CreateTeams)
+	assert.Empty(t, resp.PullRequests)
+}
+
+func TestRosterHandler_Sync_InvalidRoster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := rostermocks.NewMockSyncService(ctrl)
+	h := NewRosterHandler(svc)
+
+	svc.EXPECT().
+		Sync(gomock.Any(), gomock.Any(), false).
+		Return(nil, fmt.Errorf("%w: duplicate team %q", domain.ErrInvalidRoster, "backend"))
+
+	body := `{"teams":[{"team_name":"backend"},{"team_name":"backend"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.Sync(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var resp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "INVALID_ROSTER", resp.Error.Code)
+}
+
+func TestRosterHandler_Sync_BadBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := rostermocks.NewMockSyncService(ctrl)
+	h := NewRosterHandler(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/sync", strings.NewReader("{"))
+	w := httptest.NewRecorder()
+
+	h.Sync(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
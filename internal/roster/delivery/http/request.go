@@ -0,0 +1,17 @@
+package http
+
+type RosterMemberDTO struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	IsActive *bool  `json:"is_active,omitempty"`
+}
+
+type RosterTeamDTO struct {
+	TeamName string            `json:"team_name"`
+	Members  []RosterMemberDTO `json:"members"`
+}
+
+type SyncRequest struct {
+	Teams  []RosterTeamDTO `json:"teams"`
+	DryRun bool            `json:"dry_run"`
+}
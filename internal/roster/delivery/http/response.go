@@ -0,0 +1,42 @@
+package http
+
+import prhttp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
+
+type UserDTO struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive bool   `json:"is_active"`
+}
+
+type UserMoveDTO struct {
+	UserID   string `json:"user_id"`
+	FromTeam string `json:"from_team"`
+	ToTeam   string `json:"to_team"`
+}
+
+type UsernameChangeDTO struct {
+	UserID string `json:"user_id"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+type TeamDeactivationDTO struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type PlanDTO struct {
+	CreateTeams     []string              `json:"create_teams"`
+	CreateUsers     []UserDTO             `json:"create_users"`
+	MoveUsers       []UserMoveDTO         `json:"move_users"`
+	RenameUsers     []UsernameChangeDTO   `json:"rename_users"`
+	ActivateUsers   []string              `json:"activate_users"`
+	DeactivateUsers []TeamDeactivationDTO `json:"deactivate_users"`
+}
+
+type SyncResponse struct {
+	DryRun       bool                       `json:"dry_run"`
+	Plan         PlanDTO                    `json:"plan"`
+	PullRequests []prhttp.ReviewerChangeDTO `json:"pull_requests"`
+}
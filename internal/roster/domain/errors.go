@@ -0,0 +1,5 @@
+package domain
+
+import "errors"
+
+var ErrInvalidRoster = errors.New("invalid roster")
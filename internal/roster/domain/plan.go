@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"sort"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+)
+
+// UserMove is a user that has to change team; FromTeam is empty for a user
+// without a team.
+type UserMove struct {
+	UserID   string
+	FromTeam string
+	ToTeam   string
+}
+
+// UsernameChange is a user whose username differs from the roster.
+type UsernameChange struct {
+	UserID string
+	From   string
+	To     string
+}
+
+// TeamDeactivation lists active members of a team that have to be deactivated,
+// named by the team they are in once the moves are applied.
+type TeamDeactivation struct {
+	TeamName string
+	UserIDs  []string
+}
+
+// Plan is the set of changes that brings the stored teams and users in line
+// with a roster. Teams missing from the roster are kept; their members are
+// deactivated like any other user the roster does not list.
+type Plan struct {
+	CreateTeams     []string
+	CreateUsers     []userdomain.User
+	MoveUsers       []UserMove
+	RenameUsers     []UsernameChange
+	ActivateUsers   []string
+	DeactivateUsers []TeamDeactivation
+}
+
+func (p Plan) Empty() bool {
+	return len(p.CreateTeams) == 0 &&
+		len(p.CreateUsers) == 0 &&
+		len(p.MoveUsers) == 0 &&
+		len(p.RenameUsers) == 0 &&
+		len(p.ActivateUsers) == 0 &&
+		len(p.DeactivateUsers) == 0
+}
+
+// SyncResult is a computed plan and, unless it is a dry run, the reviewer
+// changes made while applying it.
+type SyncResult struct {
+	DryRun       bool
+	Plan         Plan
+	PullRequests []prdomain.ReviewerChange
+}
+
+// Diff compares the current teams with their live members, plus the users that
+// have no team, to the roster.
+func Diff(current []*teamdomain.Team, teamless []userdomain.User, r Roster) Plan {
+	type state struct {
+		teamName string
+		member   teamdomain.TeamMember
+	}
+
+	existingTeams := make(map[string]struct{}, len(current))
+	users := make(map[string]state)
+	for _, u := range teamless {
+		users[u.UserID] = state{member: teamdomain.TeamMember{
+			UserID:   u.UserID,
+			Username: u.Username,
+			IsActive: u.IsActive,
+		}}
+	}
+	for _, t := range current {
+		existingTeams[t.TeamName] = struct{}{}
+		for _, m := range t.Members {
+			users[m.UserID] = state{teamName: t.TeamName, member: m}
+		}
+	}
+
+	var plan Plan
+	listed := make(map[string]struct{})
+	deactivate := make(map[string][]string)
+
+	for _, t := range r.Teams {
+		if _, ok := existingTeams[t.TeamName]; !ok {
+			plan.CreateTeams = append(plan.CreateTeams, t.TeamName)
+		}
+
+		for _, m := range t.Members {
+			listed[m.UserID] = struct{}{}
+
+			cur, ok := users[m.UserID]
+			if !ok {
+				plan.CreateUsers = append(plan.CreateUsers, userdomain.User{
+					UserID:   m.UserID,
+					Username: m.Username,
+					TeamName: t.TeamName,
+					IsActive: m.IsActive,
+				})
+				continue
+			}
+
+			if cur.teamName != t.TeamName {
+				plan.MoveUsers = append(plan.MoveUsers, UserMove{
+					UserID:   m.UserID,
+					FromTeam: cur.teamName,
+					ToTeam:   t.TeamName,
+				})
+			}
+			if cur.member.Username != m.Username {
+				plan.RenameUsers = append(plan.RenameUsers, UsernameChange{
+					UserID: m.UserID,
+					From:   cur.member.Username,
+					To:     m.Username,
+				})
+			}
+			switch {
+			case m.IsActive && !cur.member.IsActive:
+				plan.ActivateUsers = append(plan.ActivateUsers, m.UserID)
+			case !m.IsActive && cur.member.IsActive:
+				deactivate[t.TeamName] = append(deactivate[t.TeamName], m.UserID)
+			}
+		}
+	}
+
+	for _, t := range current {
+		for _, m := range t.Members {
+			if _, ok := listed[m.UserID]; ok || !m.IsActive {
+				continue
+			}
+			deactivate[t.TeamName] = append(deactivate[t.TeamName], m.UserID)
+		}
+	}
+
+	teamNames := make([]string, 0, len(deactivate))
+	for name := range deactivate {
+		teamNames = append(teamNames, name)
+	}
+	sort.Strings(teamNames)
+
+	for _, name := range teamNames {
+		plan.DeactivateUsers = append(plan.DeactivateUsers, TeamDeactivation{
+			TeamName: name,
+			UserIDs:  deactivate[name],
+		})
+	}
+
+	return plan
+}
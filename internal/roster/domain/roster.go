@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+)
+
+// Roster is the desired org structure: every team with its full member list.
+type Roster struct {
+	Teams []Team
+}
+
+type Team struct {
+	TeamName string
+	Members  []Member
+}
+
+type Member struct {
+	UserID   string
+	Username string
+	IsActive bool
+}
+
+// NewMember builds a member from a roster file or request, where is_active is
+// optional: members are active unless it is set to false.
+func NewMember(userID, username string, isActive *bool) Member {
+	return Member{
+		UserID:   userID,
+		Username: username,
+		IsActive: isActive == nil || *isActive,
+	}
+}
+
+// Validate checks that every team and member is complete and that neither a
+// team nor a user is listed twice.
+func (r Roster) Validate() error {
+	teams := make(map[string]struct{}, len(r.Teams))
+	users := make(map[string]string)
+
+	for _, t := range r.Teams {
+		if t.TeamName == "" {
+			return fmt.Errorf("%w: team_name is required", ErrInvalidRoster)
+		}
+		if _, dup := teams[t.TeamName]; dup {
+			return fmt.Errorf("%w: duplicate team %q", ErrInvalidRoster, t.TeamName)
+		}
+		teams[t.TeamName] = struct{}{}
+
+		for _, m := range t.Members {
+			u := userdomain.User{UserID: m.UserID, Username: m.Username, TeamName: t.TeamName}
+			if err := u.Validate(); err != nil {
+				return fmt.Errorf("%w: team %q: %w", ErrInvalidRoster, t.TeamName, err)
+			}
+			if other, dup := users[m.UserID]; dup {
+				return fmt.Errorf("%w: user %q is listed in %q and %q", ErrInvalidRoster, m.UserID, other, t.TeamName)
+			}
+			users[m.UserID] = t.TeamName
+		}
+	}
+
+	return nil
+}
+
+type rosterFile struct {
+	Teams []struct {
+		TeamName string `yaml:"team_name"`
+		Members  []struct {
+			UserID   string `yaml:"user_id"`
+			Username string `yaml:"username"`
+			IsActive *bool  `yaml:"is_active"`
+		} `yaml:"members"`
+	} `yaml:"teams"`
+}
+
+// Parse reads a roster file in YAML or JSON.
+func Parse(data []byte) (*Roster, error) {
+	var f rosterFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRoster, err)
+	}
+
+	r := &Roster{Teams: make([]Team, 0, len(f.Teams))}
+	for _, ft := range f.Teams {
+		t := Team{TeamName: ft.TeamName, Members: make([]Member, 0, len(ft.Members))}
+		for _, fm := range ft.Members {
+			t.Members = append(t.Members, NewMember(fm.UserID, fm.Username, fm.IsActive))
+		}
+		r.Teams = append(r.Teams, t)
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
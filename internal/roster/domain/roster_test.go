@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+)
+
+func TestParse_YAML(t *testing.T) {
+	data := []byte(`
+teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+        is_active: false
+  - team_name: frontend
+`)
+
+	r, err := Parse(data)
+	require.NoError(t, err)
+
+	require.Len(t, r.Teams, 2)
+	assert.Equal(t, []Member{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: false},
+	}, r.Teams[0].Members)
+	assert.Equal(t, "frontend", r.Teams[1].TeamName)
+	assert.Empty(t, r.Teams[1].Members)
+}
+
+func TestParse_JSON(t *testing.T) {
+	data := []byte(`{"teams":[{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}]}`)
+
+	r, err := Parse(data)
+	require.NoError(t, err)
+
+	require.Len(t, r.Teams, 1)
+	assert.Equal(t, "backend", r.Teams[0].TeamName)
+	assert.Equal(t, []Member{{UserID: "u1", Username: "Alice", IsActive: true}}, r.Teams[0].Members)
+}
+
+func TestParse_Malformed(t *testing.T) {
+	_, err := Parse([]byte("teams: ["))
+	require.ErrorIs(t, err, ErrInvalidRoster)
+}
+
+func TestNewMember(t *testing.T) {
+	active, inactive := true, false
+
+	assert.True(t, NewMember("u1", "Alice", nil).IsActive)
+	assert.True(t, NewMember("u1", "Alice", &active).IsActive)
+	assert.False(t, NewMember("u1", "Alice", &inactive).IsActive)
+}
+
+func TestRoster_Validate(t *testing.T) {
+	member := func(id string) Member { return Member{UserID: id, Username: id, IsActive: true} }
+
+	tests := []struct {
+		name   string
+		roster Roster
+	}{
+		{"empty team name", Roster{Teams: []Team{{TeamName: ""}}}},
+		{"duplicate team", Roster{Teams: []Team{{TeamName: "a"}, {TeamName: "a"}}}},
+		{"missing username", Roster{Teams: []Team{{TeamName: "a", Members: []Member{{UserID: "u1"}}}}}},
+		{"user in two teams", Roster{Teams: []Team{
+			{TeamName: "a", Members: []Member{member("u1")}},
+			{TeamName: "b", Members: []Member{member("u1")}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.roster.Validate(), ErrInvalidRoster)
+		})
+	}
+
+	require.NoError(t, Roster{Teams: []Team{{TeamName: "a", Members: []Member{member("u1")}}}}.Validate())
+}
+
+func TestDiff(t *testing.T) {
+	current := []*teamdomain.Team{
+		{TeamName: "backend", Members: []teamdomain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: false},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		}},
+		{TeamName: "legacy", Members: []teamdomain.TeamMember{
+			{UserID: "u5", Username: "Eve", IsActive: true},
+			{UserID: "u6", Username: "Frank", IsActive: false},
+		}},
+	}
+
+	r := Roster{Teams: []Team{
+		{TeamName: "backend", Members: []Member{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: false},
+		}},
+		{TeamName: "platform", Members: []Member{
+			{UserID: "u2", Username: "Robert", IsActive: true},
+			{UserID: "u7", Username: "Grace", IsActive: true},
+		}},
+	}}
+
+	plan := Diff(current, nil, r)
+
+	assert.Equal(t, Plan{
+		CreateTeams: []string{"platform"},
+		CreateUsers: []userdomain.User{
+			{UserID: "u7", Username: "Grace", TeamName: "platform", IsActive: true},
+		},
+		MoveUsers:     []UserMove{{UserID: "u2", FromTeam: "backend", ToTeam: "platform"}},
+		RenameUsers:   []UsernameChange{{UserID: "u2", From: "Bob", To: "Robert"}},
+		ActivateUsers: []string{"u3"},
+		DeactivateUsers: []TeamDeactivation{
+			{TeamName: "backend", UserIDs: []string{"u4"}},
+			{TeamName: "legacy", UserIDs: []string{"u5"}},
+		},
+	}, plan)
+}
+
+func TestDiff_Teamless(t *testing.T) {
+	teamless := []userdomain.User{
+		{UserID: "u1", Username: "Alice", IsActive: false},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}
+
+	r := Roster{Teams: []Team{
+		{TeamName: "backend", Members: []Member{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+		}},
+	}}
+
+	assert.Equal(t, Plan{
+		CreateTeams:   []string{"backend"},
+		MoveUsers:     []UserMove{{UserID: "u1", FromTeam: "", ToTeam: "backend"}},
+		ActivateUsers: []string{"u1"},
+	}, Diff(nil, teamless, r))
+}
+
+func TestDiff_InSync(t *testing.T) {
+	current := []*teamdomain.Team{
+		{TeamName: "backend", Members: []teamdomain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		}},
+	}
+
+	r := Roster{Teams: []Team{
+		{TeamName: "backend", Members: []Member{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+		}},
+	}}
+
+	assert.True(t, Diff(current, nil, r).Empty())
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/roster/delivery/http/handler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/dunooo0ooo/avito-test-task/internal/roster/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSyncService is a mock of SyncService interface.
+type MockSyncService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncServiceMockRecorder
+}
+
+// MockSyncServiceMockRecorder is the mock recorder for MockSyncService.
+type MockSyncServiceMockRecorder struct {
+	mock *MockSyncService
+}
+
+// NewMockSyncService creates a new mock instance.
+func NewMockSyncService(ctrl *gomock.Controller) *MockSyncService {
+	mock := &MockSyncService{ctrl: ctrl}
+	mock.recorder = &MockSyncServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncService) EXPECT() *MockSyncServiceMockRecorder {
+	return m.recorder
+}
+
+// Sync mocks base method.
+func (m *MockSyncService) Sync(ctx context.Context, r domain.Roster, dryRun bool) (*domain.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, r, dryRun)
+	ret0, _ := ret[0].(*domain.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockSyncServiceMockRecorder) Sync(ctx, r, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockSyncService)(nil).Sync), ctx, r, dryRun)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/roster/application/service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// DeactivateTeamUsersAndReassign mocks base method.
+func (m *MockUserService) DeactivateTeamUsersAndReassign(ctx context.Context, teamName string, userIDs []string, dryRun bool) (*domain.DeactivationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTeamUsersAndReassign", ctx, teamName, userIDs, dryRun)
+	ret0, _ := ret[0].(*domain.DeactivationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTeamUsersAndReassign indicates an expected call of DeactivateTeamUsersAndReassign.
+func (mr *MockUserServiceMockRecorder) DeactivateTeamUsersAndReassign(ctx, teamName, userIDs, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeamUsersAndReassign", reflect.TypeOf((*MockUserService)(nil).DeactivateTeamUsersAndReassign), ctx, teamName, userIDs, dryRun)
+}
+
+// MoveTeam mocks base method.
+func (m *MockUserService) MoveTeam(ctx context.Context, userID, toTeam string, reassign bool) (*domain.TeamMoveResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTeam", ctx, userID, toTeam, reassign)
+	ret0, _ := ret[0].(*domain.TeamMoveResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTeam indicates an expected call of MoveTeam.
+func (mr *MockUserServiceMockRecorder) MoveTeam(ctx, userID, toTeam, reassign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTeam", reflect.TypeOf((*MockUserService)(nil).MoveTeam), ctx, userID, toTeam, reassign)
+}
@@ -2,21 +2,35 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/dunooo0ooo/avito-test-task/internal/team/domain"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
 	"go.uber.org/zap"
 )
 
 type TeamService struct {
 	teams  domain.TeamRepository
 	users  userdomain.UserRepository
+	tx     txmanager.TxManager
 	logger *zap.Logger
 }
 
-func NewTeamService(teams domain.TeamRepository, users userdomain.UserRepository, logger *zap.Logger) *TeamService {
+func NewTeamService(
+	teams domain.TeamRepository,
+	users userdomain.UserRepository,
+	tx txmanager.TxManager,
+	logger *zap.Logger,
+) *TeamService {
+	if tx == nil {
+		tx = txmanager.Nop{}
+	}
+
 	return &TeamService{
 		teams:  teams,
 		users:  users,
+		tx:     tx,
 		logger: logger,
 	}
 }
@@ -25,6 +39,25 @@ func (s *TeamService) CreateTeam(
 	ctx context.Context,
 	teamName string,
 	members []domain.TeamMember,
+) (*domain.Team, error) {
+	var created *domain.Team
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = s.createTeam(ctx, teamName, members)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *TeamService) createTeam(
+	ctx context.Context,
+	teamName string,
+	members []domain.TeamMember,
 ) (*domain.Team, error) {
 	t := &domain.Team{
 		TeamName: teamName,
@@ -119,3 +152,351 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*domain.Tea
 
 	return team, nil
 }
+
+// AddMembers adds users to an existing team; members already in the team get
+// their username and active flag updated, users without a team join it. Users of other teams are rejected so
+// that nobody changes team silently, /users/moveTeam is the way to move them.
+func (s *TeamService) AddMembers(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
+	users := make([]userdomain.User, 0, len(members))
+	for _, m := range members {
+		u := userdomain.User{
+			UserID:   m.UserID,
+			Username: m.Username,
+			TeamName: teamName,
+			IsActive: m.IsActive,
+		}
+		if err := u.Validate(); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	var team *domain.Team
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := s.teams.GetByName(ctx, teamName); err != nil {
+			return err
+		}
+
+		for _, u := range users {
+			existing, err := s.users.GetByID(ctx, u.UserID)
+			switch {
+			case errors.Is(err, userdomain.ErrUserNotFound):
+				continue
+			case err != nil:
+				return err
+			case existing.TeamName != "" && existing.TeamName != teamName:
+				return fmt.Errorf("%w: %s is in %s", domain.ErrMemberOfOtherTeam, u.UserID, existing.TeamName)
+			}
+		}
+
+		if len(users) > 0 {
+			if err := s.users.AddTeamMembers(ctx, teamName, users); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		team, err = s.teams.GetByName(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to add team members",
+				zap.String("team_name", teamName),
+				zap.Int("members_count", len(members)),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("team members added",
+			zap.String("team_name", teamName),
+			zap.Int("members_count", len(members)),
+		)
+	}
+
+	return team, nil
+}
+
+// ListTeams returns every team ordered by name with its member counts.
+func (s *TeamService) ListTeams(ctx context.Context) ([]domain.TeamSummary, error) {
+	teams, err := s.teams.List(ctx)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list teams", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	res := make([]domain.TeamSummary, 0, len(teams))
+	for _, t := range teams {
+		summary := domain.TeamSummary{
+			TeamName:     t.TeamName,
+			MembersCount: len(t.Members),
+		}
+		for _, m := range t.Members {
+			if m.IsActive {
+				summary.ActiveCount++
+			}
+		}
+		res = append(res, summary)
+	}
+
+	return res, nil
+}
+
+// RenameTeam renames the team; members, its code owners, "@team" owner entries
+// and fallback lists of other teams follow the new name.
+func (s *TeamService) RenameTeam(ctx context.Context, oldName, newName string) (*domain.Team, error) {
+	if newName == "" || newName == oldName {
+		return nil, fmt.Errorf("%w: new_team_name must be set and differ from team_name", domain.ErrInvalidTeamName)
+	}
+
+	var renamed *domain.Team
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.teams.Rename(ctx, oldName, newName); err != nil {
+			return err
+		}
+
+		var err error
+		renamed, err = s.teams.GetByName(ctx, newName)
+		return err
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to rename team",
+				zap.String("team_name", oldName),
+				zap.String("new_team_name", newName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("team renamed",
+			zap.String("team_name", oldName),
+			zap.String("new_team_name", newName),
+		)
+	}
+
+	return renamed, nil
+}
+
+// DeleteTeam removes the team. A team with members can only be deleted when
+// moveTo names another team: members are moved there first and each move is
+// recorded like /users/moveTeam, keeping their open reviews.
+func (s *TeamService) DeleteTeam(ctx context.Context, teamName, moveTo string) (*domain.TeamDeletion, error) {
+	var res *domain.TeamDeletion
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.deleteTeam(ctx, teamName, moveTo)
+		return err
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to delete team",
+				zap.String("team_name", teamName),
+				zap.String("move_members_to", moveTo),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("team deleted",
+			zap.String("team_name", teamName),
+			zap.Strings("moved_members", res.MovedMembers),
+		)
+	}
+
+	return res, nil
+}
+
+func (s *TeamService) deleteTeam(ctx context.Context, teamName, moveTo string) (*domain.TeamDeletion, error) {
+	team, err := s.teams.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &domain.TeamDeletion{TeamName: teamName}
+
+	if len(team.Members) > 0 {
+		if moveTo == "" {
+			return nil, fmt.Errorf("%w: %s has %d members", domain.ErrTeamNotEmpty, teamName, len(team.Members))
+		}
+		if moveTo == teamName {
+			return nil, fmt.Errorf("%w: cannot move members to the deleted team", domain.ErrInvalidTeamName)
+		}
+		if _, err := s.teams.GetSettings(ctx, moveTo); err != nil {
+			return nil, err
+		}
+
+		for _, m := range team.Members {
+			move := userdomain.TeamMove{
+				UserID:   m.UserID,
+				FromTeam: teamName,
+				ToTeam:   moveTo,
+			}
+			if err := s.users.MoveToTeam(ctx, &move); err != nil {
+				return nil, err
+			}
+			res.MovedMembers = append(res.MovedMembers, m.UserID)
+		}
+		res.MovedTo = moveTo
+	}
+
+	if err := s.teams.Delete(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *TeamService) GetSettings(ctx context.Context, teamName string) (*domain.Settings, error) {
+	settings, err := s.teams.GetSettings(ctx, teamName)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to get team settings",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings merges the update into the stored settings and validates the
+// result, so a request may change a single field.
+func (s *TeamService) UpdateSettings(
+	ctx context.Context,
+	teamName string,
+	update domain.SettingsUpdate,
+) (*domain.Settings, error) {
+	var updated *domain.Settings
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.teams.GetSettings(ctx, teamName)
+		if err != nil {
+			return err
+		}
+
+		settings := update.Apply(*current)
+		if err := settings.Validate(); err != nil {
+			return err
+		}
+		if err := s.checkFallbackTeams(ctx, teamName, settings.FallbackTeams); err != nil {
+			return err
+		}
+
+		if err := s.teams.UpdateSettings(ctx, teamName, settings); err != nil {
+			return err
+		}
+
+		updated, err = s.teams.GetSettings(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to update team settings",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("team settings updated",
+			zap.String("team_name", teamName),
+			zap.Int("min_reviewers", updated.MinReviewers),
+			zap.Int("max_reviewers", updated.MaxReviewers),
+			zap.Bool("self_team_only", updated.SelfTeamOnly),
+		)
+	}
+
+	return updated, nil
+}
+
+func (s *TeamService) checkFallbackTeams(ctx context.Context, teamName string, fallbackTeams []string) error {
+	for _, name := range fallbackTeams {
+		if name == teamName {
+			return fmt.Errorf("%w: team cannot be its own fallback", domain.ErrInvalidSettings)
+		}
+
+		if _, err := s.teams.GetSettings(ctx, name); err != nil {
+			if errors.Is(err, domain.ErrTeamNotFound) {
+				return fmt.Errorf("%w: unknown fallback team %q", domain.ErrInvalidSettings, name)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TeamService) GetCodeOwners(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	rules, err := s.teams.GetCodeOwners(ctx, teamName)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to get code owners",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// ReplaceCodeOwners stores the team's ruleset, dropping the previous one.
+func (s *TeamService) ReplaceCodeOwners(
+	ctx context.Context,
+	teamName string,
+	rules []domain.CodeOwnerRule,
+) ([]domain.CodeOwnerRule, error) {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var stored []domain.CodeOwnerRule
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.teams.ReplaceCodeOwners(ctx, teamName, rules); err != nil {
+			return err
+		}
+
+		var err error
+		stored, err = s.teams.GetCodeOwners(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to replace code owners",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("code owners updated",
+			zap.String("team_name", teamName),
+			zap.Int("rules_count", len(stored)),
+		)
+	}
+
+	return stored, nil
+}
@@ -23,7 +23,7 @@ func TestTeamService_CreateTeam_Success(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewTeamService(teamRepo, userRepo, logger)
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
 	ctx := context.Background()
 
 	teamName := "backend"
@@ -75,7 +75,7 @@ func TestTeamService_CreateTeam_CreateError(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewTeamService(teamRepo, userRepo, logger)
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
 	ctx := context.Background()
 
 	teamName := "backend"
@@ -103,7 +103,7 @@ func TestTeamService_CreateTeam_AddTeamMembersError(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewTeamService(teamRepo, userRepo, logger)
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
 	ctx := context.Background()
 
 	teamName := "backend"
@@ -137,7 +137,7 @@ func TestTeamService_CreateTeam_ListByTeamError(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewTeamService(teamRepo, userRepo, logger)
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
 	ctx := context.Background()
 
 	teamName := "backend"
@@ -175,7 +175,7 @@ func TestTeamService_GetTeam_Success(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewTeamService(teamRepo, userRepo, logger)
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
 	ctx := context.Background()
 
 	teamName := "backend"
@@ -207,7 +207,7 @@ func TestTeamService_GetTeam_Error(t *testing.T) {
 	userRepo := usermocks.NewMockUserRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewTeamService(teamRepo, userRepo, logger)
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
 	ctx := context.Background()
 
 	teamName := "backend"
@@ -222,3 +222,350 @@ func TestTeamService_GetTeam_Error(t *testing.T) {
 	assert.True(t, errors.Is(err, expectedErr))
 	assert.Nil(t, result)
 }
+
+func TestTeamService_UpdateSettings_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	current := teamdomain.Settings{MinReviewers: 1, MaxReviewers: 2, SelfTeamOnly: true, MaxOpenReviewsPerUser: 5}
+	maxReviewers := 3
+	want := teamdomain.Settings{MinReviewers: 1, MaxReviewers: 3, SelfTeamOnly: true, MaxOpenReviewsPerUser: 5}
+
+	gomock.InOrder(
+		teamRepo.EXPECT().
+			GetSettings(gomock.Any(), "backend").
+			Return(&current, nil),
+		teamRepo.EXPECT().
+			UpdateSettings(gomock.Any(), "backend", want).
+			Return(nil),
+		teamRepo.EXPECT().
+			GetSettings(gomock.Any(), "backend").
+			Return(&want, nil),
+	)
+
+	result, err := svc.UpdateSettings(ctx, "backend", teamdomain.SettingsUpdate{MaxReviewers: &maxReviewers})
+	require.NoError(t, err)
+	assert.Equal(t, want, *result)
+}
+
+func TestTeamService_UpdateSettings_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	current := teamdomain.DefaultSettings()
+	minReviewers := 3
+
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&current, nil)
+
+	result, err := svc.UpdateSettings(ctx, "backend", teamdomain.SettingsUpdate{MinReviewers: &minReviewers})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrInvalidSettings))
+	assert.Nil(t, result)
+}
+
+func TestTeamService_UpdateSettings_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "missing").
+		Return(nil, teamdomain.ErrTeamNotFound)
+
+	result, err := svc.UpdateSettings(ctx, "missing", teamdomain.SettingsUpdate{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrTeamNotFound))
+	assert.Nil(t, result)
+}
+
+func TestTeamService_UpdateSettings_UnknownFallbackTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	current := teamdomain.DefaultSettings()
+	selfTeamOnly := false
+	fallbackTeams := []string{"platform"}
+
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&current, nil)
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "platform").
+		Return(nil, teamdomain.ErrTeamNotFound)
+
+	result, err := svc.UpdateSettings(ctx, "backend", teamdomain.SettingsUpdate{
+		SelfTeamOnly:  &selfTeamOnly,
+		FallbackTeams: &fallbackTeams,
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrInvalidSettings))
+	assert.Nil(t, result)
+}
+
+func TestTeamService_UpdateSettings_SelfFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	current := teamdomain.Settings{MaxReviewers: 2}
+	fallbackTeams := []string{"backend"}
+
+	teamRepo.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&current, nil)
+
+	result, err := svc.UpdateSettings(ctx, "backend", teamdomain.SettingsUpdate{FallbackTeams: &fallbackTeams})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrInvalidSettings))
+	assert.Nil(t, result)
+}
+
+func TestTeamService_ReplaceCodeOwners_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	rules := []teamdomain.CodeOwnerRule{
+		{Pattern: "deploy/", Owners: []string{"@infra"}},
+	}
+
+	gomock.InOrder(
+		teamRepo.EXPECT().
+			ReplaceCodeOwners(gomock.Any(), "backend", rules).
+			Return(nil),
+		teamRepo.EXPECT().
+			GetCodeOwners(gomock.Any(), "backend").
+			Return(rules, nil),
+	)
+
+	result, err := svc.ReplaceCodeOwners(ctx, "backend", rules)
+	require.NoError(t, err)
+	assert.Equal(t, rules, result)
+}
+
+func TestTeamService_ReplaceCodeOwners_InvalidRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	logger := zap.NewNop()
+
+	svc := NewTeamService(teamRepo, userRepo, nil, logger)
+	ctx := context.Background()
+
+	result, err := svc.ReplaceCodeOwners(ctx, "backend", []teamdomain.CodeOwnerRule{
+		{Pattern: "deploy/"},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrInvalidCodeOwners))
+	assert.Nil(t, result)
+}
+
+func TestTeamService_ListTeams_CountsMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	teamRepo.EXPECT().
+		List(gomock.Any()).
+		Return([]*teamdomain.Team{
+			{TeamName: "backend", Members: []teamdomain.TeamMember{
+				{UserID: "u1", IsActive: true},
+				{UserID: "u2", IsActive: false},
+			}},
+			{TeamName: "frontend"},
+		}, nil)
+
+	teams, err := svc.ListTeams(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []teamdomain.TeamSummary{
+		{TeamName: "backend", MembersCount: 2, ActiveCount: 1},
+		{TeamName: "frontend"},
+	}, teams)
+}
+
+func TestTeamService_RenameTeam_SameName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	team, err := svc.RenameTeam(context.Background(), "backend", "backend")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrInvalidTeamName))
+	assert.Nil(t, team)
+}
+
+func TestTeamService_RenameTeam_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	gomock.InOrder(
+		teamRepo.EXPECT().Rename(gomock.Any(), "backend", "platform").Return(nil),
+		teamRepo.EXPECT().GetByName(gomock.Any(), "platform").Return(&teamdomain.Team{TeamName: "platform"}, nil),
+	)
+
+	team, err := svc.RenameTeam(context.Background(), "backend", "platform")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", team.TeamName)
+}
+
+func TestTeamService_DeleteTeam_NotEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	teamRepo.EXPECT().
+		GetByName(gomock.Any(), "backend").
+		Return(&teamdomain.Team{TeamName: "backend", Members: []teamdomain.TeamMember{{UserID: "u1"}}}, nil)
+
+	res, err := svc.DeleteTeam(context.Background(), "backend", "")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrTeamNotEmpty))
+	assert.Nil(t, res)
+}
+
+func TestTeamService_DeleteTeam_MovesMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	gomock.InOrder(
+		teamRepo.EXPECT().
+			GetByName(gomock.Any(), "backend").
+			Return(&teamdomain.Team{TeamName: "backend", Members: []teamdomain.TeamMember{{UserID: "u1"}, {UserID: "u2"}}}, nil),
+		teamRepo.EXPECT().
+			GetSettings(gomock.Any(), "platform").
+			Return(&teamdomain.Settings{MaxReviewers: 2}, nil),
+		userRepo.EXPECT().
+			MoveToTeam(gomock.Any(), &userdomain.TeamMove{UserID: "u1", FromTeam: "backend", ToTeam: "platform"}).
+			Return(nil),
+		userRepo.EXPECT().
+			MoveToTeam(gomock.Any(), &userdomain.TeamMove{UserID: "u2", FromTeam: "backend", ToTeam: "platform"}).
+			Return(nil),
+		teamRepo.EXPECT().Delete(gomock.Any(), "backend").Return(nil),
+	)
+
+	res, err := svc.DeleteTeam(context.Background(), "backend", "platform")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", res.MovedTo)
+	assert.Equal(t, []string{"u1", "u2"}, res.MovedMembers)
+}
+
+func TestTeamService_AddMembers_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	updated := &teamdomain.Team{TeamName: "backend", Members: []teamdomain.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}}
+
+	gomock.InOrder(
+		teamRepo.EXPECT().GetByName(gomock.Any(), "backend").Return(&teamdomain.Team{TeamName: "backend"}, nil),
+		userRepo.EXPECT().GetByID(gomock.Any(), "u2").Return(nil, userdomain.ErrUserNotFound),
+		userRepo.EXPECT().
+			AddTeamMembers(gomock.Any(), "backend", []userdomain.User{
+				{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+			}).
+			Return(nil),
+		teamRepo.EXPECT().GetByName(gomock.Any(), "backend").Return(updated, nil),
+	)
+
+	team, err := svc.AddMembers(context.Background(), "backend", []teamdomain.TeamMember{
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, updated, team)
+}
+
+func TestTeamService_AddMembers_MemberOfOtherTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	teamRepo := teammocks.NewMockTeamRepository(ctrl)
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+
+	svc := NewTeamService(teamRepo, userRepo, nil, zap.NewNop())
+
+	gomock.InOrder(
+		teamRepo.EXPECT().GetByName(gomock.Any(), "backend").Return(&teamdomain.Team{TeamName: "backend"}, nil),
+		userRepo.EXPECT().
+			GetByID(gomock.Any(), "u2").
+			Return(&userdomain.User{UserID: "u2", TeamName: "frontend", IsActive: true}, nil),
+	)
+
+	team, err := svc.AddMembers(context.Background(), "backend", []teamdomain.TeamMember{
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, teamdomain.ErrMemberOfOtherTeam))
+	assert.Nil(t, team)
+}
@@ -7,12 +7,21 @@ import (
 	"net/http"
 
 	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
 	"github.com/dunooo0ooo/avito-test-task/pkg/httpcommon"
 )
 
 type TeamService interface {
 	CreateTeam(ctx context.Context, teamName string, members []teamdomain.TeamMember) (*teamdomain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*teamdomain.Team, error)
+	ListTeams(ctx context.Context) ([]teamdomain.TeamSummary, error)
+	AddMembers(ctx context.Context, teamName string, members []teamdomain.TeamMember) (*teamdomain.Team, error)
+	RenameTeam(ctx context.Context, oldName, newName string) (*teamdomain.Team, error)
+	DeleteTeam(ctx context.Context, teamName, moveTo string) (*teamdomain.TeamDeletion, error)
+	GetSettings(ctx context.Context, teamName string) (*teamdomain.Settings, error)
+	UpdateSettings(ctx context.Context, teamName string, update teamdomain.SettingsUpdate) (*teamdomain.Settings, error)
+	GetCodeOwners(ctx context.Context, teamName string) ([]teamdomain.CodeOwnerRule, error)
+	ReplaceCodeOwners(ctx context.Context, teamName string, rules []teamdomain.CodeOwnerRule) ([]teamdomain.CodeOwnerRule, error)
 }
 
 type TeamHandler struct {
@@ -28,6 +37,14 @@ func NewTeamHandler(teams TeamService) *TeamHandler {
 func (h *TeamHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /team/add", h.AddTeam)
 	mux.HandleFunc("GET /team/get", h.GetTeam)
+	mux.HandleFunc("GET /team/list", h.ListTeams)
+	mux.HandleFunc("POST /team/addMembers", h.AddMembers)
+	mux.HandleFunc("POST /team/rename", h.RenameTeam)
+	mux.HandleFunc("POST /team/delete", h.DeleteTeam)
+	mux.HandleFunc("GET /team/settings", h.GetSettings)
+	mux.HandleFunc("POST /team/settings", h.UpdateSettings)
+	mux.HandleFunc("GET /team/codeowners", h.GetCodeOwners)
+	mux.HandleFunc("POST /team/codeowners", h.ReplaceCodeOwners)
 }
 
 func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
@@ -52,6 +69,10 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case errors.Is(err, teamdomain.ErrTeamAlreadyExists):
 			httpcommon.JSONError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
+		case errors.Is(err, teamdomain.ErrMemberOfOtherTeam):
+			httpcommon.JSONError(w, http.StatusConflict, "MEMBER_OF_OTHER_TEAM", err.Error())
+		case errors.Is(err, userdomain.ErrUserExists):
+			httpcommon.JSONError(w, http.StatusConflict, "USER_EXISTS", err.Error())
 		case errors.Is(err, teamdomain.ErrInternalDatabase):
 			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
 		default:
@@ -115,3 +136,294 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 	httpcommon.JSONResponse(w, http.StatusOK, resp)
 }
+
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := h.teams.ListTeams(r.Context())
+	if err != nil {
+		httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		return
+	}
+
+	resp := ListTeamsResponse{
+		Teams: make([]TeamSummaryDTO, 0, len(teams)),
+	}
+	for _, t := range teams {
+		resp.Teams = append(resp.Teams, TeamSummaryDTO{
+			TeamName:     t.TeamName,
+			MembersCount: t.MembersCount,
+			ActiveCount:  t.ActiveCount,
+		})
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func (h *TeamHandler) AddMembers(w http.ResponseWriter, r *http.Request) {
+	var req AddTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.Name == "" || len(req.Members) == 0 {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name and members are required")
+		return
+	}
+
+	members := make([]teamdomain.TeamMember, 0, len(req.Members))
+	for _, m := range req.Members {
+		members = append(members, teamdomain.TeamMember{
+			UserID:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		})
+	}
+
+	team, err := h.teams.AddMembers(r.Context(), req.Name, members)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, AddTeamResponse{Team: toTeamDTO(team)})
+}
+
+func (h *TeamHandler) RenameTeam(w http.ResponseWriter, r *http.Request) {
+	var req RenameTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.TeamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	team, err := h.teams.RenameTeam(r.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, AddTeamResponse{Team: toTeamDTO(team)})
+}
+
+func (h *TeamHandler) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	var req DeleteTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.TeamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	res, err := h.teams.DeleteTeam(r.Context(), req.TeamName, req.MoveMembersTo)
+	if err != nil {
+		writeTeamError(w, err)
+		return
+	}
+
+	resp := DeleteTeamResponse{
+		TeamName:     res.TeamName,
+		MovedTo:      res.MovedTo,
+		MovedMembers: append([]string{}, res.MovedMembers...),
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func writeTeamError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, teamdomain.ErrTeamNotFound):
+		httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.Is(err, teamdomain.ErrTeamAlreadyExists):
+		httpcommon.JSONError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
+	case errors.Is(err, teamdomain.ErrTeamNotEmpty):
+		httpcommon.JSONError(w, http.StatusConflict, "TEAM_NOT_EMPTY", err.Error())
+	case errors.Is(err, teamdomain.ErrMemberOfOtherTeam):
+		httpcommon.JSONError(w, http.StatusConflict, "MEMBER_OF_OTHER_TEAM", err.Error())
+	case errors.Is(err, userdomain.ErrUserExists):
+		httpcommon.JSONError(w, http.StatusConflict, "USER_EXISTS", err.Error())
+	case errors.Is(err, userdomain.ErrInvalidUser):
+		httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_USER", err.Error())
+	case errors.Is(err, teamdomain.ErrInvalidTeamName):
+		httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_TEAM_NAME", err.Error())
+	default:
+		httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+	}
+}
+
+func toTeamDTO(team *teamdomain.Team) TeamDTO {
+	members := make([]TeamMemberDTO, 0, len(team.Members))
+	for _, m := range team.Members {
+		members = append(members, TeamMemberDTO{
+			UserID:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		})
+	}
+
+	return TeamDTO{
+		TeamName: team.TeamName,
+		Members:  members,
+	}
+}
+
+func (h *TeamHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	settings, err := h.teams.GetSettings(r.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, teamdomain.ErrTeamNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		case errors.Is(err, teamdomain.ErrInternalDatabase):
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, toTeamSettingsDTO(teamName, settings))
+}
+
+func (h *TeamHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req UpdateSettingsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	settings, err := h.teams.UpdateSettings(r.Context(), req.TeamName, teamdomain.SettingsUpdate{
+		MinReviewers:  req.MinReviewers,
+		MaxReviewers:  req.MaxReviewers,
+		SelfTeamOnly:  req.SelfTeamOnly,
+		FallbackTeams: req.FallbackTeams,
+
+		MaxOpenReviewsPerUser: req.MaxOpenReviewsPerUser,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, teamdomain.ErrTeamNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		case errors.Is(err, teamdomain.ErrInvalidSettings):
+			httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_SETTINGS", err.Error())
+		case errors.Is(err, teamdomain.ErrInternalDatabase):
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, toTeamSettingsDTO(req.TeamName, settings))
+}
+
+func toTeamSettingsDTO(teamName string, s *teamdomain.Settings) TeamSettingsDTO {
+	fallbackTeams := s.FallbackTeams
+	if fallbackTeams == nil {
+		fallbackTeams = []string{}
+	}
+
+	return TeamSettingsDTO{
+		TeamName:      teamName,
+		MinReviewers:  s.MinReviewers,
+		MaxReviewers:  s.MaxReviewers,
+		SelfTeamOnly:  s.SelfTeamOnly,
+		FallbackTeams: fallbackTeams,
+
+		MaxOpenReviewsPerUser: s.MaxOpenReviewsPerUser,
+	}
+}
+
+func (h *TeamHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	rules, err := h.teams.GetCodeOwners(r.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, teamdomain.ErrTeamNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		case errors.Is(err, teamdomain.ErrInternalDatabase):
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, toCodeOwnersDTO(teamName, rules))
+}
+
+func (h *TeamHandler) ReplaceCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var req ReplaceCodeOwnersRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	if req.TeamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	rules := make([]teamdomain.CodeOwnerRule, 0, len(req.Rules))
+	for _, rule := range req.Rules {
+		rules = append(rules, teamdomain.CodeOwnerRule{
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		})
+	}
+
+	stored, err := h.teams.ReplaceCodeOwners(r.Context(), req.TeamName, rules)
+	if err != nil {
+		switch {
+		case errors.Is(err, teamdomain.ErrTeamNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		case errors.Is(err, teamdomain.ErrInvalidCodeOwners):
+			httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_CODEOWNERS", err.Error())
+		case errors.Is(err, teamdomain.ErrInternalDatabase):
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		default:
+			httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		}
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, toCodeOwnersDTO(req.TeamName, stored))
+}
+
+func toCodeOwnersDTO(teamName string, rules []teamdomain.CodeOwnerRule) CodeOwnersDTO {
+	dtos := make([]CodeOwnerRuleDTO, 0, len(rules))
+	for _, rule := range rules {
+		dtos = append(dtos, CodeOwnerRuleDTO{
+			Pattern: rule.Pattern,
+			Owners:  rule.Owners,
+		})
+	}
+
+	return CodeOwnersDTO{
+		TeamName: teamName,
+		Rules:    dtos,
+	}
+}
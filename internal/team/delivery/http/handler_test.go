@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -145,6 +146,36 @@ func TestTeamHandler_AddTeam_TeamExists(t *testing.T) {
 	assert.Equal(t, "team_name already exists", errResp.Error.Message)
 }
 
+func TestTeamHandler_AddTeam_MemberOfOtherTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	body := `{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`
+
+	svc.EXPECT().
+		CreateTeam(gomock.Any(), "backend", gomock.Any()).
+		Return(nil, fmt.Errorf("%w: u1", teamdomain.ErrMemberOfOtherTeam))
+
+	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.AddTeam(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "MEMBER_OF_OTHER_TEAM", errResp.Error.Code)
+}
+
 func TestTeamHandler_AddTeam_InternalError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -311,3 +342,397 @@ func TestTeamHandler_GetTeam_InternalError(t *testing.T) {
 	assert.Equal(t, "INTERNAL_ERROR", errResp.Error.Code)
 	assert.Equal(t, "internal server error", errResp.Error.Message)
 }
+
+func TestTeamHandler_GetSettings_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		GetSettings(gomock.Any(), "backend").
+		Return(&teamdomain.Settings{MinReviewers: 1, MaxReviewers: 3, FallbackTeams: []string{"platform"}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/settings?team_name=backend", nil)
+	w := httptest.NewRecorder()
+
+	h.GetSettings(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp TeamSettingsDTO
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, TeamSettingsDTO{
+		TeamName:      "backend",
+		MinReviewers:  1,
+		MaxReviewers:  3,
+		SelfTeamOnly:  false,
+		FallbackTeams: []string{"platform"},
+	}, resp)
+}
+
+func TestTeamHandler_UpdateSettings_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	maxReviewers, selfTeamOnly := 3, false
+	settings := teamdomain.Settings{MinReviewers: 1, MaxReviewers: 3, SelfTeamOnly: false}
+
+	svc.EXPECT().
+		UpdateSettings(gomock.Any(), "backend", teamdomain.SettingsUpdate{
+			MaxReviewers: &maxReviewers,
+			SelfTeamOnly: &selfTeamOnly,
+		}).
+		Return(&settings, nil)
+
+	body := `{"team_name":"backend","max_reviewers":3,"self_team_only":false}`
+	req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.UpdateSettings(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp TeamSettingsDTO
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, 3, resp.MaxReviewers)
+	assert.False(t, resp.SelfTeamOnly)
+}
+
+func TestTeamHandler_UpdateSettings_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		UpdateSettings(gomock.Any(), "backend", gomock.Any()).
+		Return(nil, teamdomain.ErrInvalidSettings)
+
+	body := `{"team_name":"backend","min_reviewers":3,"max_reviewers":2}`
+	req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.UpdateSettings(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "INVALID_SETTINGS", errResp.Error.Code)
+}
+
+func TestTeamHandler_UpdateSettings_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		UpdateSettings(gomock.Any(), "missing", gomock.Any()).
+		Return(nil, teamdomain.ErrTeamNotFound)
+
+	body := `{"team_name":"missing","min_reviewers":0,"max_reviewers":2}`
+	req := httptest.NewRequest(http.MethodPost, "/team/settings", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.UpdateSettings(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestTeamHandler_ReplaceCodeOwners_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	rules := []teamdomain.CodeOwnerRule{
+		{Pattern: "*.go", Owners: []string{"u1"}},
+		{Pattern: "deploy/", Owners: []string{"@infra"}},
+	}
+
+	svc.EXPECT().
+		ReplaceCodeOwners(gomock.Any(), "backend", rules).
+		Return(rules, nil)
+
+	body := `{"team_name":"backend","rules":[{"pattern":"*.go","owners":["u1"]},{"pattern":"deploy/","owners":["@infra"]}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/codeowners", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.ReplaceCodeOwners(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp CodeOwnersDTO
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, "backend", resp.TeamName)
+	require.Len(t, resp.Rules, 2)
+	assert.Equal(t, "deploy/", resp.Rules[1].Pattern)
+	assert.Equal(t, []string{"@infra"}, resp.Rules[1].Owners)
+}
+
+func TestTeamHandler_ReplaceCodeOwners_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		ReplaceCodeOwners(gomock.Any(), "backend", gomock.Any()).
+		Return(nil, teamdomain.ErrInvalidCodeOwners)
+
+	body := `{"team_name":"backend","rules":[{"pattern":"[","owners":["u1"]}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/codeowners", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.ReplaceCodeOwners(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+
+	assert.Equal(t, "INVALID_CODEOWNERS", errResp.Error.Code)
+}
+
+func TestTeamHandler_GetCodeOwners_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		GetCodeOwners(gomock.Any(), "missing").
+		Return(nil, teamdomain.ErrTeamNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/codeowners?team_name=missing", nil)
+	w := httptest.NewRecorder()
+
+	h.GetCodeOwners(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestTeamHandler_ListTeams_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		ListTeams(gomock.Any()).
+		Return([]teamdomain.TeamSummary{{TeamName: "backend", MembersCount: 3, ActiveCount: 2}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/list", nil)
+	w := httptest.NewRecorder()
+
+	h.ListTeams(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ListTeamsResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, []TeamSummaryDTO{{TeamName: "backend", MembersCount: 3, ActiveCount: 2}}, resp.Teams)
+}
+
+func TestTeamHandler_RenameTeam_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		RenameTeam(gomock.Any(), "backend", "frontend").
+		Return(nil, teamdomain.ErrTeamAlreadyExists)
+
+	body := `{"team_name":"backend","new_team_name":"frontend"}`
+	req := httptest.NewRequest(http.MethodPost, "/team/rename", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.RenameTeam(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "TEAM_EXISTS", errResp.Error.Code)
+}
+
+func TestTeamHandler_DeleteTeam_NotEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		DeleteTeam(gomock.Any(), "backend", "").
+		Return(nil, teamdomain.ErrTeamNotEmpty)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/delete", bytes.NewReader([]byte(`{"team_name":"backend"}`)))
+	w := httptest.NewRecorder()
+
+	h.DeleteTeam(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "TEAM_NOT_EMPTY", errResp.Error.Code)
+}
+
+func TestTeamHandler_DeleteTeam_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		DeleteTeam(gomock.Any(), "backend", "platform").
+		Return(&teamdomain.TeamDeletion{TeamName: "backend", MovedTo: "platform", MovedMembers: []string{"u1"}}, nil)
+
+	body := `{"team_name":"backend","move_members_to":"platform"}`
+	req := httptest.NewRequest(http.MethodPost, "/team/delete", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.DeleteTeam(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp DeleteTeamResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, DeleteTeamResponse{TeamName: "backend", MovedTo: "platform", MovedMembers: []string{"u1"}}, resp)
+}
+
+func TestTeamHandler_AddMembers_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		AddMembers(gomock.Any(), "backend", []teamdomain.TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}}).
+		Return(&teamdomain.Team{TeamName: "backend", Members: []teamdomain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		}}, nil)
+
+	body := `{"team_name":"backend","members":[{"user_id":"u2","username":"Bob","is_active":true}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/addMembers", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.AddMembers(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp AddTeamResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Len(t, resp.Team.Members, 2)
+}
+
+func TestTeamHandler_AddMembers_MemberOfOtherTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := teammocks.NewMockTeamService(ctrl)
+	h := NewTeamHandler(svc)
+
+	svc.EXPECT().
+		AddMembers(gomock.Any(), "backend", gomock.Any()).
+		Return(nil, teamdomain.ErrMemberOfOtherTeam)
+
+	body := `{"team_name":"backend","members":[{"user_id":"u2","username":"Bob","is_active":true}]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/addMembers", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+
+	h.AddMembers(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "MEMBER_OF_OTHER_TEAM", errResp.Error.Code)
+}
@@ -8,3 +8,29 @@ type AddTeamRequest struct {
 type GetTeamRequest struct {
 	Name string `json:"team_name"`
 }
+
+// UpdateSettingsRequest changes only the fields present in the body.
+type UpdateSettingsRequest struct {
+	TeamName      string    `json:"team_name"`
+	MinReviewers  *int      `json:"min_reviewers"`
+	MaxReviewers  *int      `json:"max_reviewers"`
+	SelfTeamOnly  *bool     `json:"self_team_only"`
+	FallbackTeams *[]string `json:"fallback_teams"`
+
+	MaxOpenReviewsPerUser *int `json:"max_open_reviews_per_user"`
+}
+
+type ReplaceCodeOwnersRequest struct {
+	TeamName string             `json:"team_name"`
+	Rules    []CodeOwnerRuleDTO `json:"rules"`
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+type DeleteTeamRequest struct {
+	TeamName      string `json:"team_name"`
+	MoveMembersTo string `json:"move_members_to"`
+}
@@ -13,3 +13,39 @@ type TeamDTO struct {
 type AddTeamResponse struct {
 	Team TeamDTO `json:"team"`
 }
+
+type TeamSettingsDTO struct {
+	TeamName      string   `json:"team_name"`
+	MinReviewers  int      `json:"min_reviewers"`
+	MaxReviewers  int      `json:"max_reviewers"`
+	SelfTeamOnly  bool     `json:"self_team_only"`
+	FallbackTeams []string `json:"fallback_teams"`
+
+	MaxOpenReviewsPerUser int `json:"max_open_reviews_per_user"`
+}
+
+type CodeOwnerRuleDTO struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type CodeOwnersDTO struct {
+	TeamName string             `json:"team_name"`
+	Rules    []CodeOwnerRuleDTO `json:"rules"`
+}
+
+type TeamSummaryDTO struct {
+	TeamName     string `json:"team_name"`
+	MembersCount int    `json:"members_count"`
+	ActiveCount  int    `json:"active_count"`
+}
+
+type ListTeamsResponse struct {
+	Teams []TeamSummaryDTO `json:"teams"`
+}
+
+type DeleteTeamResponse struct {
+	TeamName     string   `json:"team_name"`
+	MovedTo      string   `json:"moved_to,omitempty"`
+	MovedMembers []string `json:"moved_members"`
+}
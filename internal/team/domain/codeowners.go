@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// TeamOwnerPrefix marks an owner entry that refers to a whole team, as in "@backend".
+const TeamOwnerPrefix = "@"
+
+// CodeOwnerRule assigns owners to the files matching Pattern, CODEOWNERS-style:
+// a pattern without a slash matches the file name in any directory, a trailing
+// slash or "/**" matches everything under a directory, anything else (including
+// a pattern anchored with a leading slash) is matched against the full path. Owners are user IDs or team names prefixed with "@".
+type CodeOwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+func (r CodeOwnerRule) Validate() error {
+	if r.Pattern == "" {
+		return fmt.Errorf("%w: empty code owners pattern", ErrInvalidCodeOwners)
+	}
+	if _, err := path.Match(strings.TrimSuffix(strings.TrimPrefix(r.Pattern, "/"), "/**"), ""); err != nil {
+		return fmt.Errorf("%w: bad pattern %q", ErrInvalidCodeOwners, r.Pattern)
+	}
+	if len(r.Owners) == 0 {
+		return fmt.Errorf("%w: pattern %q has no owners", ErrInvalidCodeOwners, r.Pattern)
+	}
+	for _, o := range r.Owners {
+		if o == "" || o == TeamOwnerPrefix {
+			return fmt.Errorf("%w: empty owner for pattern %q", ErrInvalidCodeOwners, r.Pattern)
+		}
+	}
+	return nil
+}
+
+func (r CodeOwnerRule) Matches(filePath string) bool {
+	filePath = strings.TrimPrefix(filePath, "/")
+	pattern, anchored := strings.CutPrefix(r.Pattern, "/")
+
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return matchDir(dir, filePath)
+	}
+	if dir, ok := strings.CutSuffix(pattern, "/"); ok {
+		return matchDir(dir, filePath)
+	}
+	if !anchored && !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(filePath))
+		return ok
+	}
+
+	ok, _ := path.Match(pattern, filePath)
+	return ok
+}
+
+// matchDir reports whether filePath lies under a directory matching dir.
+func matchDir(dir, filePath string) bool {
+	dirParts := strings.Split(dir, "/")
+	fileParts := strings.Split(filePath, "/")
+	if len(fileParts) <= len(dirParts) {
+		return false
+	}
+
+	ok, _ := path.Match(dir, strings.Join(fileParts[:len(dirParts)], "/"))
+	return ok
+}
+
+// MatchCodeOwners returns the rule that owns filePath; as in CODEOWNERS the last
+// matching rule wins.
+func MatchCodeOwners(rules []CodeOwnerRule, filePath string) (CodeOwnerRule, bool) {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].Matches(filePath) {
+			return rules[i], true
+		}
+	}
+	return CodeOwnerRule{}, false
+}
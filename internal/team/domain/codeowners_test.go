@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOwnerRule_Matches(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		match         bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "internal/app/router.go", true},
+		{"*.go", "README.md", false},
+		{"deploy/", "deploy/k8s/app.yaml", true},
+		{"deploy/", "deploy", false},
+		{"deploy/", "cmd/deploy/main.go", false},
+		{"/internal/*/infra/**", "internal/team/infra/postgres/team.go", true},
+		{"/internal/*/infra/**", "internal/team/domain/team.go", false},
+		{"migrations/*.sql", "migrations/00001_add_teams_table.sql", true},
+		{"migrations/*.sql", "migrations/old/00001.sql", false},
+		{"README.md", "docs/README.md", true},
+		{"/README.md", "README.md", true},
+		{"/README.md", "docs/README.md", false},
+		{"/*.md", "docs/README.md", false},
+	}
+
+	for _, tc := range tests {
+		rule := CodeOwnerRule{Pattern: tc.pattern, Owners: []string{"u1"}}
+		assert.Equal(t, tc.match, rule.Matches(tc.path), "%s ~ %s", tc.pattern, tc.path)
+	}
+}
+
+func TestMatchCodeOwners_LastRuleWins(t *testing.T) {
+	rules := []CodeOwnerRule{
+		{Pattern: "*.go", Owners: []string{"u1"}},
+		{Pattern: "internal/team/", Owners: []string{"@backend"}},
+	}
+
+	rule, ok := MatchCodeOwners(rules, "internal/team/domain/team.go")
+	assert.True(t, ok)
+	assert.Equal(t, "internal/team/", rule.Pattern)
+
+	rule, ok = MatchCodeOwners(rules, "cmd/main.go")
+	assert.True(t, ok)
+	assert.Equal(t, "*.go", rule.Pattern)
+
+	_, ok = MatchCodeOwners(rules, "README.md")
+	assert.False(t, ok)
+}
+
+func TestCodeOwnerRule_Validate(t *testing.T) {
+	assert.NoError(t, CodeOwnerRule{Pattern: "docs/**", Owners: []string{"@docs"}}.Validate())
+
+	for _, rule := range []CodeOwnerRule{
+		{Pattern: "", Owners: []string{"u1"}},
+		{Pattern: "[", Owners: []string{"u1"}},
+		{Pattern: "*.go"},
+		{Pattern: "*.go", Owners: []string{"@"}},
+	} {
+		err := rule.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidCodeOwners), "%+v", rule)
+	}
+}
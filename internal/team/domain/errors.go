@@ -5,5 +5,10 @@ import "errors"
 var (
 	ErrTeamNotFound      = errors.New("team not found")
 	ErrTeamAlreadyExists = errors.New("team already exists")
+	ErrTeamNotEmpty      = errors.New("team has members")
+	ErrMemberOfOtherTeam = errors.New("user belongs to another team")
+	ErrInvalidTeamName   = errors.New("invalid team name")
+	ErrInvalidSettings   = errors.New("invalid team settings")
+	ErrInvalidCodeOwners = errors.New("invalid code owners")
 	ErrInternalDatabase  = errors.New("user: internal database error")
 )
@@ -7,5 +7,17 @@ import (
 type TeamRepository interface {
 	Create(ctx context.Context, t *Team) error
 	GetByName(ctx context.Context, name string) (*Team, error)
+	// List returns all teams ordered by name.
 	List(ctx context.Context) ([]*Team, error)
+	// Rename changes the team name everywhere it is referenced: members, its
+	// code owners, "@team" owners in other teams' rules and fallback lists.
+	Rename(ctx context.Context, oldName, newName string) error
+	// Delete removes the team, its code owners, its "@team" owner entries (rules
+	// left without owners are dropped) and its place in fallback lists. Live
+	// members must be moved out first; deleted users lose their team.
+	Delete(ctx context.Context, name string) error
+	GetSettings(ctx context.Context, name string) (*Settings, error)
+	UpdateSettings(ctx context.Context, name string, settings Settings) error
+	GetCodeOwners(ctx context.Context, name string) ([]CodeOwnerRule, error)
+	ReplaceCodeOwners(ctx context.Context, name string, rules []CodeOwnerRule) error
 }
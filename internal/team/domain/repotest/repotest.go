@@ -0,0 +1,298 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Deps is what the suite needs from a storage backend. SeedMembers must add
+// the given users to an existing team.
+type Deps struct {
+	Teams       domain.TeamRepository
+	SeedMembers func(t *testing.T, teamName string, members ...domain.TeamMember)
+}
+
+// Run checks that a TeamRepository behaves like the postgres one.
+// newDeps is called for every subtest and must return an empty storage.
+func Run(t *testing.T, newDeps func(t *testing.T) Deps) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, d Deps)
+	}{
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateDuplicate", testCreateDuplicate},
+		{"GetByNameNotFound", testGetByNameNotFound},
+		{"MembersOrderedByID", testMembersOrderedByID},
+		{"List", testList},
+		{"SettingsDefaults", testSettingsDefaults},
+		{"UpdateSettings", testUpdateSettings},
+		{"SettingsNotFound", testSettingsNotFound},
+		{"ReplaceCodeOwners", testReplaceCodeOwners},
+		{"CodeOwnersNotFound", testCodeOwnersNotFound},
+		{"Rename", testRename},
+		{"RenameConflicts", testRenameConflicts},
+		{"Delete", testDelete},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newDeps(t))
+		})
+	}
+}
+
+func testCreateAndGet(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+
+	team, err := d.Teams.GetByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.TeamName)
+	assert.Empty(t, team.Members)
+}
+
+func testCreateDuplicate(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+
+	err := d.Teams.Create(ctx, &domain.Team{TeamName: "backend"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrTeamAlreadyExists))
+}
+
+func testGetByNameNotFound(t *testing.T, d Deps) {
+	team, err := d.Teams.GetByName(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+	assert.Nil(t, team)
+}
+
+func testMembersOrderedByID(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+	d.SeedMembers(t, "backend",
+		domain.TeamMember{UserID: "u3", Username: "Carol", IsActive: true},
+		domain.TeamMember{UserID: "u1", Username: "Alice", IsActive: false},
+		domain.TeamMember{UserID: "u2", Username: "Bob", IsActive: true},
+	)
+
+	team, err := d.Teams.GetByName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: false},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+		{UserID: "u3", Username: "Carol", IsActive: true},
+	}, team.Members)
+}
+
+func testList(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	teams, err := d.Teams.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "frontend"}))
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+	d.SeedMembers(t, "backend",
+		domain.TeamMember{UserID: "u2", Username: "Bob", IsActive: true},
+		domain.TeamMember{UserID: "u1", Username: "Alice", IsActive: true},
+	)
+
+	teams, err = d.Teams.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+
+	assert.Equal(t, "backend", teams[0].TeamName)
+	assert.Equal(t, []domain.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}, teams[0].Members)
+	assert.Equal(t, "frontend", teams[1].TeamName)
+	assert.Empty(t, teams[1].Members)
+}
+
+func testSettingsDefaults(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+
+	settings, err := d.Teams.GetSettings(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultSettings(), *settings)
+}
+
+func testUpdateSettings(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+
+	want := domain.Settings{
+		MinReviewers:  1,
+		MaxReviewers:  3,
+		SelfTeamOnly:  false,
+		FallbackTeams: []string{"platform", "infra"},
+	}
+	require.NoError(t, d.Teams.UpdateSettings(ctx, "backend", want))
+
+	settings, err := d.Teams.GetSettings(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, want, *settings)
+}
+
+func testSettingsNotFound(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	settings, err := d.Teams.GetSettings(ctx, "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+	assert.Nil(t, settings)
+
+	err = d.Teams.UpdateSettings(ctx, "missing", domain.DefaultSettings())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+}
+
+func testReplaceCodeOwners(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+
+	rules, err := d.Teams.GetCodeOwners(ctx, "backend")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	first := []domain.CodeOwnerRule{
+		{Pattern: "*.go", Owners: []string{"u1"}},
+		{Pattern: "deploy/", Owners: []string{"@infra", "u2"}},
+	}
+	require.NoError(t, d.Teams.ReplaceCodeOwners(ctx, "backend", first))
+
+	rules, err = d.Teams.GetCodeOwners(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, first, rules)
+
+	second := []domain.CodeOwnerRule{
+		{Pattern: "docs/**", Owners: []string{"u3"}},
+	}
+	require.NoError(t, d.Teams.ReplaceCodeOwners(ctx, "backend", second))
+
+	rules, err = d.Teams.GetCodeOwners(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, second, rules)
+}
+
+func testCodeOwnersNotFound(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	rules, err := d.Teams.GetCodeOwners(ctx, "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+	assert.Nil(t, rules)
+
+	err = d.Teams.ReplaceCodeOwners(ctx, "missing", nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+}
+
+func testRename(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "frontend"}))
+	d.SeedMembers(t, "backend", domain.TeamMember{UserID: "u1", Username: "Alice", IsActive: true})
+	require.NoError(t, d.Teams.ReplaceCodeOwners(ctx, "backend", []domain.CodeOwnerRule{
+		{Pattern: "api/", Owners: []string{"u1"}},
+	}))
+	require.NoError(t, d.Teams.ReplaceCodeOwners(ctx, "frontend", []domain.CodeOwnerRule{
+		{Pattern: "*.proto", Owners: []string{"@backend", "u9"}},
+	}))
+	require.NoError(t, d.Teams.UpdateSettings(ctx, "frontend", domain.Settings{
+		MaxReviewers:  2,
+		FallbackTeams: []string{"backend"},
+	}))
+
+	require.NoError(t, d.Teams.Rename(ctx, "backend", "platform"))
+
+	_, err := d.Teams.GetByName(ctx, "backend")
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+
+	team, err := d.Teams.GetByName(ctx, "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}, team.Members)
+
+	rules, err := d.Teams.GetCodeOwners(ctx, "platform")
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+
+	rules, err = d.Teams.GetCodeOwners(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CodeOwnerRule{{Pattern: "*.proto", Owners: []string{"@platform", "u9"}}}, rules)
+
+	settings, err := d.Teams.GetSettings(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform"}, settings.FallbackTeams)
+}
+
+func testRenameConflicts(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "frontend"}))
+
+	err := d.Teams.Rename(ctx, "backend", "frontend")
+	assert.True(t, errors.Is(err, domain.ErrTeamAlreadyExists))
+
+	err = d.Teams.Rename(ctx, "missing", "platform")
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+}
+
+func testDelete(t *testing.T, d Deps) {
+	ctx := context.Background()
+
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "backend"}))
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "frontend"}))
+	require.NoError(t, d.Teams.Create(ctx, &domain.Team{TeamName: "infra"}))
+	d.SeedMembers(t, "frontend", domain.TeamMember{UserID: "u1", Username: "Alice", IsActive: true})
+	require.NoError(t, d.Teams.UpdateSettings(ctx, "infra", domain.Settings{
+		MaxReviewers:  2,
+		FallbackTeams: []string{"backend", "frontend"},
+	}))
+	require.NoError(t, d.Teams.ReplaceCodeOwners(ctx, "infra", []domain.CodeOwnerRule{
+		{Pattern: "api/", Owners: []string{"@backend"}},
+		{Pattern: "deploy/", Owners: []string{"u2", "@backend", "@frontend"}},
+		{Pattern: "*.tf", Owners: []string{"u3"}},
+	}))
+
+	err := d.Teams.Delete(ctx, "frontend")
+	assert.True(t, errors.Is(err, domain.ErrTeamNotEmpty))
+
+	require.NoError(t, d.Teams.Delete(ctx, "backend"))
+
+	_, err = d.Teams.GetByName(ctx, "backend")
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+
+	settings, err := d.Teams.GetSettings(ctx, "infra")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, settings.FallbackTeams)
+
+	rules, err := d.Teams.GetCodeOwners(ctx, "infra")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CodeOwnerRule{
+		{Pattern: "deploy/", Owners: []string{"u2", "@frontend"}},
+		{Pattern: "*.tf", Owners: []string{"u3"}},
+	}, rules)
+
+	err = d.Teams.Delete(ctx, "backend")
+	assert.True(t, errors.Is(err, domain.ErrTeamNotFound))
+}
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"fmt"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+)
+
 type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
@@ -10,3 +16,101 @@ type Team struct {
 	TeamName string
 	Members  []TeamMember
 }
+
+// TeamSummary is a team with its member counts, as shown in the team list.
+type TeamSummary struct {
+	TeamName     string
+	MembersCount int
+	ActiveCount  int
+}
+
+// TeamDeletion reports a deleted team and the members moved out of it.
+type TeamDeletion struct {
+	TeamName     string
+	MovedTo      string
+	MovedMembers []string
+}
+
+const MaxReviewersLimit = prdomain.MaxReviewers
+
+// Settings control reviewer assignment for pull requests authored by team members.
+// SelfTeamOnly restricts candidates to the author's team; otherwise missing slots
+// are filled from FallbackTeams in order, or from any other team when none are set.
+// MaxOpenReviewsPerUser is the default review capacity of members, zero means unlimited.
+type Settings struct {
+	MinReviewers  int
+	MaxReviewers  int
+	SelfTeamOnly  bool
+	FallbackTeams []string
+
+	MaxOpenReviewsPerUser int
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		MinReviewers: 0,
+		MaxReviewers: 2,
+		SelfTeamOnly: true,
+	}
+}
+
+func (s Settings) Validate() error {
+	if s.MinReviewers < 0 {
+		return fmt.Errorf("%w: min_reviewers must not be negative", ErrInvalidSettings)
+	}
+	if s.MaxReviewers < 1 || s.MaxReviewers > MaxReviewersLimit {
+		return fmt.Errorf("%w: max_reviewers must be between 1 and %d", ErrInvalidSettings, MaxReviewersLimit)
+	}
+	if s.MinReviewers > s.MaxReviewers {
+		return fmt.Errorf("%w: min_reviewers must not exceed max_reviewers", ErrInvalidSettings)
+	}
+	if s.MaxOpenReviewsPerUser < 0 {
+		return fmt.Errorf("%w: max_open_reviews_per_user must not be negative", ErrInvalidSettings)
+	}
+	if s.SelfTeamOnly && len(s.FallbackTeams) > 0 {
+		return fmt.Errorf("%w: fallback_teams require self_team_only to be false", ErrInvalidSettings)
+	}
+
+	seen := make(map[string]struct{}, len(s.FallbackTeams))
+	for _, name := range s.FallbackTeams {
+		if name == "" {
+			return fmt.Errorf("%w: empty fallback team name", ErrInvalidSettings)
+		}
+		if _, dup := seen[name]; dup {
+			return fmt.Errorf("%w: duplicate fallback team %q", ErrInvalidSettings, name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+// SettingsUpdate changes some of the team settings; nil fields keep the stored
+// values.
+type SettingsUpdate struct {
+	MinReviewers  *int
+	MaxReviewers  *int
+	SelfTeamOnly  *bool
+	FallbackTeams *[]string
+
+	MaxOpenReviewsPerUser *int
+}
+
+// Apply returns s with the fields set in u replaced.
+func (u SettingsUpdate) Apply(s Settings) Settings {
+	if u.MinReviewers != nil {
+		s.MinReviewers = *u.MinReviewers
+	}
+	if u.MaxReviewers != nil {
+		s.MaxReviewers = *u.MaxReviewers
+	}
+	if u.SelfTeamOnly != nil {
+		s.SelfTeamOnly = *u.SelfTeamOnly
+	}
+	if u.FallbackTeams != nil {
+		s.FallbackTeams = *u.FallbackTeams
+	}
+	if u.MaxOpenReviewsPerUser != nil {
+		s.MaxOpenReviewsPerUser = *u.MaxOpenReviewsPerUser
+	}
+	return s
+}
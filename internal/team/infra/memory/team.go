@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+)
+
+type Repository struct {
+	store *memstore.Store
+}
+
+func NewTeamRepository(store *memstore.Store) *Repository {
+	return &Repository{store: store}
+}
+
+func (r *Repository) Create(ctx context.Context, team *domain.Team) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Teams[team.TeamName]; ok {
+			return fmt.Errorf("%w: %s", domain.ErrTeamAlreadyExists, team.TeamName)
+		}
+
+		defaults := domain.DefaultSettings()
+		t.Teams[team.TeamName] = memstore.Team{
+			TeamName:     team.TeamName,
+			MinReviewers: defaults.MinReviewers,
+			MaxReviewers: defaults.MaxReviewers,
+			SelfTeamOnly: defaults.SelfTeamOnly,
+			CreatedAt:    time.Now().UTC(),
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	var (
+		team  *domain.Team
+		found bool
+	)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		if _, found = t.Teams[name]; found {
+			team = &domain.Team{
+				TeamName: name,
+				Members:  membersOf(t, name),
+			}
+		}
+	})
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+	}
+
+	return team, nil
+}
+
+func (r *Repository) List(ctx context.Context) ([]*domain.Team, error) {
+	var result []*domain.Team
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		result = make([]*domain.Team, 0, len(t.Teams))
+		for name := range t.Teams {
+			result = append(result, &domain.Team{
+				TeamName: name,
+				Members:  membersOf(t, name),
+			})
+		}
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TeamName < result[j].TeamName
+	})
+
+	return result, nil
+}
+
+func (r *Repository) Rename(ctx context.Context, oldName, newName string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := t.Teams[oldName]
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, oldName)
+		}
+		if _, taken := t.Teams[newName]; taken {
+			return fmt.Errorf("%w: %s", domain.ErrTeamAlreadyExists, newName)
+		}
+
+		row.TeamName = newName
+		delete(t.Teams, oldName)
+		t.Teams[newName] = row
+
+		for id, u := range t.Users {
+			if u.TeamName == oldName {
+				u.TeamName = newName
+				t.Users[id] = u
+			}
+		}
+
+		if rules, ok := t.CodeOwners[oldName]; ok {
+			delete(t.CodeOwners, oldName)
+			t.CodeOwners[newName] = rules
+		}
+
+		for name, team := range t.Teams {
+			for i, fb := range team.FallbackTeams {
+				if fb == oldName {
+					team.FallbackTeams[i] = newName
+				}
+			}
+			t.Teams[name] = team
+		}
+
+		oldOwner, newOwner := domain.TeamOwnerPrefix+oldName, domain.TeamOwnerPrefix+newName
+		for _, rules := range t.CodeOwners {
+			for _, rule := range rules {
+				for i, o := range rule.Owners {
+					if o == oldOwner {
+						rule.Owners[i] = newOwner
+					}
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) Delete(ctx context.Context, name string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Teams[name]; !ok {
+			return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+		}
+		if len(membersOf(t, name)) > 0 {
+			return fmt.Errorf("%w: %s", domain.ErrTeamNotEmpty, name)
+		}
+
+		delete(t.Teams, name)
+		delete(t.CodeOwners, name)
+
+		for id, u := range t.Users {
+			if u.TeamName == name {
+				u.TeamName = ""
+				t.Users[id] = u
+			}
+		}
+
+		for teamName, team := range t.Teams {
+			team.FallbackTeams = slices.DeleteFunc(team.FallbackTeams, func(fb string) bool {
+				return fb == name
+			})
+			t.Teams[teamName] = team
+		}
+
+		owner := domain.TeamOwnerPrefix + name
+		for teamName, rules := range t.CodeOwners {
+			kept := rules[:0]
+			for _, rule := range rules {
+				rule.Owners = slices.DeleteFunc(rule.Owners, func(o string) bool {
+					return o == owner
+				})
+				if len(rule.Owners) > 0 {
+					kept = append(kept, rule)
+				}
+			}
+			t.CodeOwners[teamName] = kept
+		}
+
+		return nil
+	})
+}
+
+func membersOf(t *memstore.Tables, teamName string) []domain.TeamMember {
+	var members []domain.TeamMember
+	for _, u := range t.Users {
+		if u.TeamName != teamName || u.DeletedAt != nil {
+			continue
+		}
+		members = append(members, domain.TeamMember{
+			UserID:   u.UserID,
+			Username: u.Username,
+			IsActive: u.IsActive,
+		})
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserID < members[j].UserID
+	})
+
+	return members
+}
+
+func (r *Repository) GetSettings(ctx context.Context, name string) (*domain.Settings, error) {
+	var (
+		row   memstore.Team
+		found bool
+	)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		row, found = t.Teams[name]
+	})
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+	}
+
+	return &domain.Settings{
+		MinReviewers:  row.MinReviewers,
+		MaxReviewers:  row.MaxReviewers,
+		SelfTeamOnly:  row.SelfTeamOnly,
+		FallbackTeams: append([]string(nil), row.FallbackTeams...),
+
+		MaxOpenReviewsPerUser: row.MaxOpenReviewsPerUser,
+	}, nil
+}
+
+func (r *Repository) UpdateSettings(ctx context.Context, name string, settings domain.Settings) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := t.Teams[name]
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+		}
+
+		row.MinReviewers = settings.MinReviewers
+		row.MaxReviewers = settings.MaxReviewers
+		row.SelfTeamOnly = settings.SelfTeamOnly
+		row.FallbackTeams = append([]string(nil), settings.FallbackTeams...)
+		row.MaxOpenReviewsPerUser = settings.MaxOpenReviewsPerUser
+		t.Teams[name] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) GetCodeOwners(ctx context.Context, name string) ([]domain.CodeOwnerRule, error) {
+	var (
+		rules []domain.CodeOwnerRule
+		found bool
+	)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		if _, found = t.Teams[name]; !found {
+			return
+		}
+		for _, rule := range t.CodeOwners[name] {
+			rules = append(rules, domain.CodeOwnerRule{
+				Pattern: rule.Pattern,
+				Owners:  append([]string(nil), rule.Owners...),
+			})
+		}
+	})
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+	}
+
+	return rules, nil
+}
+
+func (r *Repository) ReplaceCodeOwners(ctx context.Context, name string, rules []domain.CodeOwnerRule) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Teams[name]; !ok {
+			return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+		}
+
+		rows := make([]memstore.CodeOwnerRule, 0, len(rules))
+		for _, rule := range rules {
+			rows = append(rows, memstore.CodeOwnerRule{
+				Pattern: rule.Pattern,
+				Owners:  append([]string(nil), rule.Owners...),
+			})
+		}
+		t.CodeOwners[name] = rows
+
+		return nil
+	})
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/dunooo0ooo/avito-test-task/internal/team/domain/repotest"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	usermemory "github.com/dunooo0ooo/avito-test-task/internal/user/infra/memory"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Deps {
+		store := memstore.New()
+		users := usermemory.NewUserRepository(store)
+
+		return repotest.Deps{
+			Teams: NewTeamRepository(store),
+			SeedMembers: func(t *testing.T, teamName string, members ...domain.TeamMember) {
+				batch := make([]userdomain.User, 0, len(members))
+				for _, m := range members {
+					batch = append(batch, userdomain.User{UserID: m.UserID, Username: m.Username, IsActive: m.IsActive})
+				}
+				require.NoError(t, users.AddTeamMembers(context.Background(), teamName, batch))
+			},
+		}
+	})
+}
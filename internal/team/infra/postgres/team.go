@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -21,6 +23,10 @@ func NewTeamRepository(pool *pgxpool.Pool) *Repository {
 	}
 }
 
+func (r *Repository) conn(ctx context.Context) txmanager.Querier {
+	return txmanager.Conn(ctx, r.pool)
+}
+
 func (r *Repository) Create(ctx context.Context, t *domain.Team) error {
 	const query = `
 		INSERT INTO teams (team_name)
@@ -31,7 +37,7 @@ func (r *Repository) Create(ctx context.Context, t *domain.Team) error {
 		"name": t.TeamName,
 	}
 
-	_, err := r.pool.Exec(ctx, query, args)
+	_, err := r.conn(ctx).Exec(ctx, query, args)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -50,7 +56,7 @@ func (r *Repository) GetByName(ctx context.Context, name string) (*domain.Team,
 		WHERE team_name = @name
 	`
 
-	if err := r.pool.QueryRow(ctx, teamQuery, pgx.NamedArgs{"name": name}).Scan(new(string)); err != nil {
+	if err := r.conn(ctx).QueryRow(ctx, teamQuery, pgx.NamedArgs{"name": name}).Scan(new(string)); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%w: %w", domain.ErrTeamNotFound, err)
 		}
@@ -61,10 +67,11 @@ func (r *Repository) GetByName(ctx context.Context, name string) (*domain.Team,
 		SELECT user_id, username, is_active
 		FROM users
 		WHERE team_name = @name
+		  AND deleted_at IS NULL
 		ORDER BY user_id
 	`
 
-	rows, err := r.pool.Query(ctx, membersQuery, pgx.NamedArgs{"name": name})
+	rows, err := r.conn(ctx).Query(ctx, membersQuery, pgx.NamedArgs{"name": name})
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -100,10 +107,11 @@ func (r *Repository) List(ctx context.Context) ([]*domain.Team, error) {
 		FROM teams t
 		LEFT JOIN users u
 			ON u.team_name = t.team_name
+		   AND u.deleted_at IS NULL
 		ORDER BY t.team_name, u.user_id
 	`
 
-	rows, err := r.pool.Query(ctx, query, nil)
+	rows, err := r.conn(ctx).Query(ctx, query, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -150,5 +158,331 @@ func (r *Repository) List(ctx context.Context) ([]*domain.Team, error) {
 		result = append(result, t)
 	}
 
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TeamName < result[j].TeamName
+	})
+
 	return result, nil
 }
+
+func (r *Repository) Rename(ctx context.Context, oldName, newName string) error {
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	// users and team_code_owners follow through ON UPDATE CASCADE.
+	const renameQuery = `
+		UPDATE teams
+		SET team_name = @new_name
+		WHERE team_name = @old_name
+	`
+
+	args := pgx.NamedArgs{
+		"old_name": oldName,
+		"new_name": newName,
+	}
+
+	cmd, err := tx.Exec(ctx, renameQuery, args)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: %w", domain.ErrTeamAlreadyExists, err)
+		}
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, oldName)
+	}
+
+	const fallbackQuery = `
+		UPDATE teams
+		SET fallback_teams = array_replace(fallback_teams, @old_name, @new_name)
+		WHERE @old_name = ANY(fallback_teams)
+	`
+
+	if _, err := tx.Exec(ctx, fallbackQuery, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	const ownersQuery = `
+		UPDATE team_code_owners
+		SET owners = array_replace(owners, @old_owner, @new_owner)
+		WHERE @old_owner = ANY(owners)
+	`
+
+	ownerArgs := pgx.NamedArgs{
+		"old_owner": domain.TeamOwnerPrefix + oldName,
+		"new_owner": domain.TeamOwnerPrefix + newName,
+	}
+
+	if _, err := tx.Exec(ctx, ownersQuery, ownerArgs); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, name string) error {
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	const membersQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM users
+			WHERE team_name = @name
+			  AND deleted_at IS NULL
+		)
+	`
+
+	var hasMembers bool
+	if err := tx.QueryRow(ctx, membersQuery, pgx.NamedArgs{"name": name}).Scan(&hasMembers); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	if hasMembers {
+		return fmt.Errorf("%w: %s", domain.ErrTeamNotEmpty, name)
+	}
+
+	// Deleted users get a NULL team and code owners go away through the foreign keys.
+	const deleteQuery = `
+		DELETE FROM teams
+		WHERE team_name = @name
+	`
+
+	cmd, err := tx.Exec(ctx, deleteQuery, pgx.NamedArgs{"name": name})
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+	}
+
+	const fallbackQuery = `
+		UPDATE teams
+		SET fallback_teams = array_remove(fallback_teams, @name)
+		WHERE @name = ANY(fallback_teams)
+	`
+
+	if _, err := tx.Exec(ctx, fallbackQuery, pgx.NamedArgs{"name": name}); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	// Rules of other teams lose the team as an owner; a rule left without
+	// owners is dropped.
+	const ownersQuery = `
+		UPDATE team_code_owners
+		SET owners = array_remove(owners, @owner)
+		WHERE @owner = ANY(owners)
+	`
+
+	ownerArgs := pgx.NamedArgs{"owner": domain.TeamOwnerPrefix + name}
+
+	if _, err := tx.Exec(ctx, ownersQuery, ownerArgs); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	const emptyRulesQuery = `
+		DELETE FROM team_code_owners
+		WHERE cardinality(owners) = 0
+	`
+
+	if _, err := tx.Exec(ctx, emptyRulesQuery); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetSettings(ctx context.Context, name string) (*domain.Settings, error) {
+	const query = `
+		SELECT min_reviewers, max_reviewers, self_team_only, fallback_teams, max_open_reviews_per_user
+		FROM teams
+		WHERE team_name = @name
+	`
+
+	var s domain.Settings
+
+	err := r.conn(ctx).QueryRow(ctx, query, pgx.NamedArgs{"name": name}).Scan(
+		&s.MinReviewers,
+		&s.MaxReviewers,
+		&s.SelfTeamOnly,
+		&s.FallbackTeams,
+		&s.MaxOpenReviewsPerUser,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %w", domain.ErrTeamNotFound, err)
+		}
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if len(s.FallbackTeams) == 0 {
+		s.FallbackTeams = nil
+	}
+
+	return &s, nil
+}
+
+func (r *Repository) UpdateSettings(ctx context.Context, name string, settings domain.Settings) error {
+	const query = `
+		UPDATE teams
+		SET min_reviewers  = @min_reviewers,
+		    max_reviewers  = @max_reviewers,
+		    self_team_only = @self_team_only,
+		    fallback_teams = @fallback_teams,
+		    max_open_reviews_per_user = @max_open_reviews_per_user
+		WHERE team_name = @name
+	`
+
+	fallbackTeams := settings.FallbackTeams
+	if fallbackTeams == nil {
+		fallbackTeams = []string{}
+	}
+
+	args := pgx.NamedArgs{
+		"name":           name,
+		"min_reviewers":  settings.MinReviewers,
+		"max_reviewers":  settings.MaxReviewers,
+		"self_team_only": settings.SelfTeamOnly,
+		"fallback_teams": fallbackTeams,
+
+		"max_open_reviews_per_user": settings.MaxOpenReviewsPerUser,
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrTeamNotFound, name)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetCodeOwners(ctx context.Context, name string) ([]domain.CodeOwnerRule, error) {
+	if err := r.ensureExists(ctx, name); err != nil {
+		return nil, err
+	}
+
+	const query = `
+		SELECT pattern, owners
+		FROM team_code_owners
+		WHERE team_name = @name
+		ORDER BY position
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, pgx.NamedArgs{"name": name})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	var rules []domain.CodeOwnerRule
+
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.Owners); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return rules, nil
+}
+
+func (r *Repository) ReplaceCodeOwners(ctx context.Context, name string, rules []domain.CodeOwnerRule) error {
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		_ = tx.Rollback(ctx)
+	}(tx, ctx)
+
+	const lockQuery = `
+		SELECT team_name
+		FROM teams
+		WHERE team_name = @name
+		FOR UPDATE
+	`
+
+	if err := tx.QueryRow(ctx, lockQuery, pgx.NamedArgs{"name": name}).Scan(new(string)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %w", domain.ErrTeamNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	const deleteQuery = `
+		DELETE FROM team_code_owners
+		WHERE team_name = @name
+	`
+
+	if _, err := tx.Exec(ctx, deleteQuery, pgx.NamedArgs{"name": name}); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	const insertQuery = `
+		INSERT INTO team_code_owners (team_name, position, pattern, owners)
+		VALUES (@name, @position, @pattern, @owners)
+	`
+
+	for i, rule := range rules {
+		args := pgx.NamedArgs{
+			"name":     name,
+			"position": i,
+			"pattern":  rule.Pattern,
+			"owners":   rule.Owners,
+		}
+		if _, err := tx.Exec(ctx, insertQuery, args); err != nil {
+			return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ensureExists(ctx context.Context, name string) error {
+	const query = `
+		SELECT team_name
+		FROM teams
+		WHERE team_name = @name
+	`
+
+	if err := r.conn(ctx).QueryRow(ctx, query, pgx.NamedArgs{"name": name}).Scan(new(string)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %w", domain.ErrTeamNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/dunooo0ooo/avito-test-task/internal/team/domain/repotest"
+	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	userpg "github.com/dunooo0ooo/avito-test-task/internal/user/infra/postgres"
+	"github.com/dunooo0ooo/avito-test-task/pkg/pgtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	pool := pgtest.Pool(t)
+	users := userpg.NewUserRepository(pool)
+
+	repotest.Run(t, func(t *testing.T) repotest.Deps {
+		pgtest.Reset(t, pool)
+
+		return repotest.Deps{
+			Teams: NewTeamRepository(pool),
+			SeedMembers: func(t *testing.T, teamName string, members ...domain.TeamMember) {
+				batch := make([]userdomain.User, 0, len(members))
+				for _, m := range members {
+					batch = append(batch, userdomain.User{UserID: m.UserID, Username: m.Username, IsActive: m.IsActive})
+				}
+				require.NoError(t, users.AddTeamMembers(context.Background(), teamName, batch))
+			},
+		}
+	})
+}
@@ -49,6 +49,20 @@ func (mr *MockTeamRepositoryMockRecorder) Create(ctx, t interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTeamRepository)(nil).Create), ctx, t)
 }
 
+// Delete mocks base method.
+func (m *MockTeamRepository) Delete(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTeamRepositoryMockRecorder) Delete(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTeamRepository)(nil).Delete), ctx, name)
+}
+
 // GetByName mocks base method.
 func (m *MockTeamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +78,36 @@ func (mr *MockTeamRepositoryMockRecorder) GetByName(ctx, name interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockTeamRepository)(nil).GetByName), ctx, name)
 }
 
+// GetCodeOwners mocks base method.
+func (m *MockTeamRepository) GetCodeOwners(ctx context.Context, name string) ([]domain.CodeOwnerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeOwners", ctx, name)
+	ret0, _ := ret[0].([]domain.CodeOwnerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeOwners indicates an expected call of GetCodeOwners.
+func (mr *MockTeamRepositoryMockRecorder) GetCodeOwners(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeOwners", reflect.TypeOf((*MockTeamRepository)(nil).GetCodeOwners), ctx, name)
+}
+
+// GetSettings mocks base method.
+func (m *MockTeamRepository) GetSettings(ctx context.Context, name string) (*domain.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, name)
+	ret0, _ := ret[0].(*domain.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockTeamRepositoryMockRecorder) GetSettings(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockTeamRepository)(nil).GetSettings), ctx, name)
+}
+
 // List mocks base method.
 func (m *MockTeamRepository) List(ctx context.Context) ([]*domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -78,3 +122,45 @@ func (mr *MockTeamRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTeamRepository)(nil).List), ctx)
 }
+
+// Rename mocks base method.
+func (m *MockTeamRepository) Rename(ctx context.Context, oldName, newName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, oldName, newName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockTeamRepositoryMockRecorder) Rename(ctx, oldName, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockTeamRepository)(nil).Rename), ctx, oldName, newName)
+}
+
+// ReplaceCodeOwners mocks base method.
+func (m *MockTeamRepository) ReplaceCodeOwners(ctx context.Context, name string, rules []domain.CodeOwnerRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCodeOwners", ctx, name, rules)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCodeOwners indicates an expected call of ReplaceCodeOwners.
+func (mr *MockTeamRepositoryMockRecorder) ReplaceCodeOwners(ctx, name, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCodeOwners", reflect.TypeOf((*MockTeamRepository)(nil).ReplaceCodeOwners), ctx, name, rules)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockTeamRepository) UpdateSettings(ctx context.Context, name string, settings domain.Settings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, name, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockTeamRepositoryMockRecorder) UpdateSettings(ctx, name, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockTeamRepository)(nil).UpdateSettings), ctx, name, settings)
+}
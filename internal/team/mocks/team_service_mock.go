@@ -35,6 +35,21 @@ func (m *MockTeamService) EXPECT() *MockTeamServiceMockRecorder {
 	return m.recorder
 }
 
+// AddMembers mocks base method.
+func (m *MockTeamService) AddMembers(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMembers", ctx, teamName, members)
+	ret0, _ := ret[0].(*domain.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMembers indicates an expected call of AddMembers.
+func (mr *MockTeamServiceMockRecorder) AddMembers(ctx, teamName, members interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMembers", reflect.TypeOf((*MockTeamService)(nil).AddMembers), ctx, teamName, members)
+}
+
 // CreateTeam mocks base method.
 func (m *MockTeamService) CreateTeam(ctx context.Context, teamName string, members []domain.TeamMember) (*domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,51 @@ func (mr *MockTeamServiceMockRecorder) CreateTeam(ctx, teamName, members interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamService)(nil).CreateTeam), ctx, teamName, members)
 }
 
+// DeleteTeam mocks base method.
+func (m *MockTeamService) DeleteTeam(ctx context.Context, teamName, moveTo string) (*domain.TeamDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTeam", ctx, teamName, moveTo)
+	ret0, _ := ret[0].(*domain.TeamDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTeam indicates an expected call of DeleteTeam.
+func (mr *MockTeamServiceMockRecorder) DeleteTeam(ctx, teamName, moveTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTeam", reflect.TypeOf((*MockTeamService)(nil).DeleteTeam), ctx, teamName, moveTo)
+}
+
+// GetCodeOwners mocks base method.
+func (m *MockTeamService) GetCodeOwners(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCodeOwners", ctx, teamName)
+	ret0, _ := ret[0].([]domain.CodeOwnerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCodeOwners indicates an expected call of GetCodeOwners.
+func (mr *MockTeamServiceMockRecorder) GetCodeOwners(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeOwners", reflect.TypeOf((*MockTeamService)(nil).GetCodeOwners), ctx, teamName)
+}
+
+// GetSettings mocks base method.
+func (m *MockTeamService) GetSettings(ctx context.Context, teamName string) (*domain.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, teamName)
+	ret0, _ := ret[0].(*domain.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockTeamServiceMockRecorder) GetSettings(ctx, teamName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockTeamService)(nil).GetSettings), ctx, teamName)
+}
+
 // GetTeam mocks base method.
 func (m *MockTeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +124,63 @@ func (mr *MockTeamServiceMockRecorder) GetTeam(ctx, teamName interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeam", reflect.TypeOf((*MockTeamService)(nil).GetTeam), ctx, teamName)
 }
+
+// ListTeams mocks base method.
+func (m *MockTeamService) ListTeams(ctx context.Context) ([]domain.TeamSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeams", ctx)
+	ret0, _ := ret[0].([]domain.TeamSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeams indicates an expected call of ListTeams.
+func (mr *MockTeamServiceMockRecorder) ListTeams(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeams", reflect.TypeOf((*MockTeamService)(nil).ListTeams), ctx)
+}
+
+// RenameTeam mocks base method.
+func (m *MockTeamService) RenameTeam(ctx context.Context, oldName, newName string) (*domain.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTeam", ctx, oldName, newName)
+	ret0, _ := ret[0].(*domain.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTeam indicates an expected call of RenameTeam.
+func (mr *MockTeamServiceMockRecorder) RenameTeam(ctx, oldName, newName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTeam", reflect.TypeOf((*MockTeamService)(nil).RenameTeam), ctx, oldName, newName)
+}
+
+// ReplaceCodeOwners mocks base method.
+func (m *MockTeamService) ReplaceCodeOwners(ctx context.Context, teamName string, rules []domain.CodeOwnerRule) ([]domain.CodeOwnerRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCodeOwners", ctx, teamName, rules)
+	ret0, _ := ret[0].([]domain.CodeOwnerRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceCodeOwners indicates an expected call of ReplaceCodeOwners.
+func (mr *MockTeamServiceMockRecorder) ReplaceCodeOwners(ctx, teamName, rules interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCodeOwners", reflect.TypeOf((*MockTeamService)(nil).ReplaceCodeOwners), ctx, teamName, rules)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockTeamService) UpdateSettings(ctx context.Context, teamName string, update domain.SettingsUpdate) (*domain.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, teamName, update)
+	ret0, _ := ret[0].(*domain.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockTeamServiceMockRecorder) UpdateSettings(ctx, teamName, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockTeamService)(nil).UpdateSettings), ctx, teamName, update)
+}
@@ -3,23 +3,46 @@ package application
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	prapp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/application"
 	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
 	"github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
 	"go.uber.org/zap"
 )
 
 type Service struct {
-	users  domain.UserRepository
-	prs    prdomain.PullRequestRepository
-	logger *zap.Logger
+	users        domain.UserRepository
+	prs          prdomain.PullRequestRepository
+	tx           txmanager.TxManager
+	selector     prapp.ReviewerSelector
+	availability *prapp.ReviewerAvailability
+	logger       *zap.Logger
 }
 
-func NewUserService(users domain.UserRepository, prs prdomain.PullRequestRepository, logger *zap.Logger) *Service {
+func NewUserService(
+	users domain.UserRepository,
+	prs prdomain.PullRequestRepository,
+	tx txmanager.TxManager,
+	selector prapp.ReviewerSelector,
+	logger *zap.Logger,
+) *Service {
+	if tx == nil {
+		tx = txmanager.Nop{}
+	}
+	if selector == nil {
+		selector = prapp.NewRandomSelector()
+	}
+
 	return &Service{
-		users:  users,
-		prs:    prs,
-		logger: logger,
+		users:        users,
+		prs:          prs,
+		tx:           tx,
+		selector:     selector,
+		availability: prapp.NewReviewerAvailability(users, prs, logger),
+		logger:       logger,
 	}
 }
 
@@ -92,13 +115,450 @@ func (s *Service) GetUserReviews(ctx context.Context, userID string) (*prdomain.
 	}, nil
 }
 
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+func (s *Service) CreateUser(ctx context.Context, u domain.User) (*domain.User, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.Create(ctx, u); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to create user",
+				zap.String("user_id", u.UserID),
+				zap.String("team_name", u.TeamName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	created, err := s.users.GetByID(ctx, u.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get user after create: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.Info("user created",
+			zap.String("user_id", u.UserID),
+			zap.String("team_name", u.TeamName),
+		)
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to get user",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func (s *Service) UpdateUsername(ctx context.Context, userID, username string) (*domain.User, error) {
+	if username == "" {
+		return nil, fmt.Errorf("%w: username is required", domain.ErrInvalidUser)
+	}
+
+	if err := s.users.UpdateUsername(ctx, userID, username); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to update username",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user after update: %w", err)
+	}
+
+	return u, nil
+}
+
+// ListUsers returns one page of users ordered by user_id. NextCursor is set
+// only when there are more results after this page.
+func (s *Service) ListUsers(ctx context.Context, filter domain.ListFilter) (*domain.UserPage, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	filter.Limit = limit + 1
+
+	users, err := s.users.List(ctx, filter)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list users",
+				zap.Any("filter", filter),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	page := &domain.UserPage{Users: users}
+
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = page.Users[limit-1].UserID
+	}
+
+	return page, nil
+}
+
+// DeleteUser removes the user from every listing. Deletion is refused while the
+// user still authors an OPEN or DRAFT pull request; their open reviews are
+// handed over to active teammates the same way as on deactivation. Merged and
+// closed pull requests keep referring to the user.
+func (s *Service) DeleteUser(ctx context.Context, userID string) ([]prdomain.ReviewerChange, error) {
+	var changes []prdomain.ReviewerChange
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		changes, err = s.deleteUser(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (s *Service) deleteUser(ctx context.Context, userID string) ([]prdomain.ReviewerChange, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNoOpenAuthored(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.users.ListByTeam(ctx, u.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidateIDs []string
+	for _, m := range members {
+		if m.IsActive && m.UserID != userID {
+			candidateIDs = append(candidateIDs, m.UserID)
+		}
+	}
+
+	leaving := []string{userID}
+
+	changes, err := s.planReassignment(ctx, u.TeamName, leaving, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyReassignment(ctx, leaving, changes); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.Delete(ctx, userID); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to delete user",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("user deleted",
+			zap.String("user_id", userID),
+			zap.Int("pull_requests_changed", len(changes)),
+		)
+	}
+
+	return changes, nil
+}
+
+// MoveTeam moves the user to another team and records the move. With reassign
+// the user's open reviews are handed over to active members of the old team,
+// otherwise the user keeps reviewing them. Authored pull requests stay as they are.
+func (s *Service) MoveTeam(ctx context.Context, userID, toTeam string, reassign bool) (*domain.TeamMoveResult, error) {
+	var res *domain.TeamMoveResult
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.moveTeam(ctx, userID, toTeam, reassign)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *Service) moveTeam(ctx context.Context, userID, toTeam string, reassign bool) (*domain.TeamMoveResult, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if u.TeamName == toTeam {
+		return nil, fmt.Errorf("%w: %s is already in %s", domain.ErrAlreadyInTeam, userID, toTeam)
+	}
+
+	move := domain.TeamMove{
+		UserID:   userID,
+		FromTeam: u.TeamName,
+		ToTeam:   toTeam,
+	}
+
+	if err := s.users.MoveToTeam(ctx, &move); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to move user to team",
+				zap.String("user_id", userID),
+				zap.String("from_team", move.FromTeam),
+				zap.String("to_team", toTeam),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	res := &domain.TeamMoveResult{Move: move, User: *u}
+	res.User.TeamName = toTeam
+
+	// A user without a team has no teammates to hand the reviews to.
+	if reassign && move.FromTeam != "" {
+		members, err := s.users.ListByTeam(ctx, move.FromTeam)
+		if err != nil {
+			return nil, err
+		}
+
+		var candidateIDs []string
+		for _, m := range members {
+			if m.IsActive {
+				candidateIDs = append(candidateIDs, m.UserID)
+			}
+		}
+
+		leaving := []string{userID}
+
+		changes, err := s.planReassignment(ctx, move.FromTeam, leaving, candidateIDs)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.applyReassignment(ctx, leaving, changes); err != nil {
+			return nil, err
+		}
+
+		res.PullRequests = changes
+	}
+
+	if s.logger != nil {
+		s.logger.Info("user moved to team",
+			zap.String("user_id", userID),
+			zap.String("from_team", move.FromTeam),
+			zap.String("to_team", toTeam),
+			zap.Int("pull_requests_changed", len(res.PullRequests)),
+		)
+	}
+
+	return res, nil
+}
+
+func (s *Service) ListTeamMoves(ctx context.Context, userID string) ([]domain.TeamMove, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	moves, err := s.users.ListTeamMoves(ctx, userID)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list team moves",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	return moves, nil
+}
+
+// checkNoOpenAuthored fails with ErrUserHasOpenPRs when the user authors an
+// OPEN or DRAFT pull request.
+func (s *Service) checkNoOpenAuthored(ctx context.Context, userID string) error {
+	for _, status := range []prdomain.PRStatus{prdomain.PRStatusOpen, prdomain.PRStatusDraft} {
+		authored, err := s.prs.List(ctx, prdomain.ListFilter{AuthorID: userID, Status: status, Limit: 1})
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Error("failed to list authored pull requests",
+					zap.String("user_id", userID),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+		if len(authored) > 0 {
+			return fmt.Errorf("%w: %s authors %s", domain.ErrUserHasOpenPRs, userID, authored[0].PullRequestID)
+		}
+	}
+
+	return nil
+}
+
+// RemoveTeamMembers takes the given members out of the team and leaves them
+// without one; their accounts stay. Their open reviews go to the remaining
+// active members, pull requests they author stay as they are. Users that are not
+// in the team are skipped.
+func (s *Service) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string) (*domain.RemovalReport, error) {
+	var report *domain.RemovalReport
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		report, err = s.removeTeamMembers(ctx, teamName, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *Service) removeTeamMembers(ctx context.Context, teamName string, userIDs []string) (*domain.RemovalReport, error) {
+	report := &domain.RemovalReport{TeamName: teamName}
+
+	members, err := s.users.ListByTeam(ctx, teamName)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list team members for removal",
+				zap.String("team_name", teamName),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	inTeam := make(map[string]struct{}, len(members))
+	for _, u := range members {
+		inTeam[u.UserID] = struct{}{}
+	}
+
+	seen := make(map[string]struct{}, len(userIDs))
+	leavingSet := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if _, ok := inTeam[id]; !ok {
+			report.Skipped = append(report.Skipped, domain.SkippedUser{UserID: id, Reason: domain.SkipReasonNotInTeam})
+			continue
+		}
+		leavingSet[id] = struct{}{}
+		report.Removed = append(report.Removed, id)
+	}
+
+	if len(report.Removed) == 0 {
+		return report, nil
+	}
+
+	var candidateIDs []string
+	for _, u := range members {
+		if _, leaving := leavingSet[u.UserID]; !leaving && u.IsActive {
+			candidateIDs = append(candidateIDs, u.UserID)
+		}
+	}
+
+	changes, err := s.planReassignment(ctx, teamName, report.Removed, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyReassignment(ctx, report.Removed, changes); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.RemoveFromTeam(ctx, teamName, report.Removed); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to remove members from team",
+				zap.String("team_name", teamName),
+				zap.Strings("user_ids", report.Removed),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	report.PullRequests = changes
+
+	if s.logger != nil {
+		s.logger.Info("team members removed",
+			zap.String("team_name", teamName),
+			zap.Strings("removed", report.Removed),
+			zap.Int("skipped", len(report.Skipped)),
+			zap.Int("pull_requests_changed", len(changes)),
+		)
+	}
+
+	return report, nil
+}
+
+// DeactivateTeamUsersAndReassign deactivates the given active members of the team
+// and hands their open reviews over to the remaining members. Everything runs in
+// one transaction with a constant number of queries regardless of team size.
+// With dryRun the report is built the same way but nothing is written.
 func (s *Service) DeactivateTeamUsersAndReassign(
 	ctx context.Context,
 	teamName string,
 	userIDs []string,
-) error {
+	dryRun bool,
+) (*domain.DeactivationReport, error) {
+	var report *domain.DeactivationReport
+
+	if dryRun {
+		ctx = prapp.WithDryRun(ctx)
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		report, err = s.deactivateTeamUsersAndReassign(ctx, teamName, userIDs, dryRun)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *Service) deactivateTeamUsersAndReassign(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+	dryRun bool,
+) (*domain.DeactivationReport, error) {
+	report := &domain.DeactivationReport{TeamName: teamName, DryRun: dryRun}
+
 	if len(userIDs) == 0 {
-		return nil
+		return report, nil
 	}
 
 	members, err := s.users.ListByTeam(ctx, teamName)
@@ -109,12 +569,28 @@ func (s *Service) DeactivateTeamUsersAndReassign(
 				zap.Error(err),
 			)
 		}
-		return err
+		return nil, err
+	}
+
+	membersByID := make(map[string]*domain.User, len(members))
+	for _, u := range members {
+		membersByID[u.UserID] = u
 	}
 
 	toDeactivateSet := make(map[string]struct{}, len(userIDs))
 	for _, id := range userIDs {
+		if _, dup := toDeactivateSet[id]; dup {
+			continue
+		}
 		toDeactivateSet[id] = struct{}{}
+
+		u, ok := membersByID[id]
+		switch {
+		case !ok:
+			report.Skipped = append(report.Skipped, domain.SkippedUser{UserID: id, Reason: domain.SkipReasonNotInTeam})
+		case !u.IsActive:
+			report.Skipped = append(report.Skipped, domain.SkippedUser{UserID: id, Reason: domain.SkipReasonAlreadyInactive})
+		}
 	}
 
 	var toDeactivate []string
@@ -132,104 +608,402 @@ func (s *Service) DeactivateTeamUsersAndReassign(
 	}
 
 	if len(toDeactivate) == 0 {
-		return nil
+		return report, nil
+	}
+
+	candidateIDs := make([]string, 0, len(stillActive))
+	for _, u := range stillActive {
+		candidateIDs = append(candidateIDs, u.UserID)
 	}
 
-	shorts, err := s.prs.ListOpenByReviewers(ctx, toDeactivate)
+	changes, err := s.planReassignment(ctx, teamName, toDeactivate, candidateIDs)
 	if err != nil {
+		return nil, err
+	}
+
+	report.Deactivated = toDeactivate
+	report.PullRequests = changes
+
+	if dryRun {
 		if s.logger != nil {
-			s.logger.Error("failed to list open PRs for deactivated reviewers",
+			s.logger.Info("deactivate & reassignment planned (dry run)",
 				zap.String("team_name", teamName),
-				zap.Strings("reviewers", toDeactivate),
+				zap.Strings("deactivated", toDeactivate),
+				zap.Int("pull_requests_changed", len(changes)),
+			)
+		}
+		return report, nil
+	}
+
+	if err := s.applyReassignment(ctx, toDeactivate, changes); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.DeactivateUsers(ctx, toDeactivate); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to deactivate users after reassignment",
+				zap.Strings("user_ids", toDeactivate),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info(" deactivate & reassignment completed",
+			zap.String("team_name", teamName),
+			zap.Strings("deactivated", toDeactivate),
+			zap.Int("skipped", len(report.Skipped)),
+			zap.Int("pull_requests_changed", len(changes)),
+		)
+	}
+
+	return report, nil
+}
+
+// SetMaxOpenReviews limits how many OPEN pull requests the user may review at
+// once; zero makes the team default apply again.
+func (s *Service) SetMaxOpenReviews(ctx context.Context, userID string, limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("%w: max_open_reviews must not be negative", domain.ErrInvalidCapacity)
+	}
+
+	if err := s.users.SetMaxOpenReviews(ctx, userID, limit); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to set user review limit",
+				zap.String("user_id", userID),
+				zap.Int("max_open_reviews", limit),
 				zap.Error(err),
 			)
 		}
 		return err
 	}
 
-	deactivatedSet := toDeactivateSet
+	if s.logger != nil {
+		s.logger.Info("user review limit updated",
+			zap.String("user_id", userID),
+			zap.Int("max_open_reviews", limit),
+		)
+	}
 
-	candidateIDs := make([]string, 0, len(stillActive))
-	for _, u := range stillActive {
-		candidateIDs = append(candidateIDs, u.UserID)
+	return nil
+}
+
+// AddAbsence records a leave period for the user. When reassign is set and
+// the leave has already started, the user's open reviews are handed over to
+// available teammates; the IDs of the affected PRs are returned.
+func (s *Service) AddAbsence(ctx context.Context, a domain.Absence, reassign bool) (*domain.Absence, []string, error) {
+	a.StartsAt = a.StartsAt.UTC()
+	a.EndsAt = a.EndsAt.UTC()
+
+	if err := a.Validate(); err != nil {
+		return nil, nil, err
 	}
 
-	for _, sh := range shorts {
-		pr, err := s.prs.GetByID(ctx, sh.PullRequestID)
+	var reassigned []string
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		u, err := s.users.GetByID(ctx, a.UserID)
 		if err != nil {
-			if s.logger != nil {
-				s.logger.Error("failed to load full PR for reassignment",
-					zap.String("pr_id", sh.PullRequestID),
-					zap.Error(err),
-				)
+			return err
+		}
+
+		if err := s.users.CreateAbsence(ctx, &a); err != nil {
+			return err
+		}
+
+		if !reassign || !a.Covers(time.Now().UTC()) {
+			return nil
+		}
+
+		members, err := s.users.ListByTeam(ctx, u.TeamName)
+		if err != nil {
+			return err
+		}
+
+		candidateIDs := make([]string, 0, len(members))
+		for _, m := range members {
+			if m.IsActive && m.UserID != u.UserID {
+				candidateIDs = append(candidateIDs, m.UserID)
 			}
+		}
+
+		changes, err := s.planReassignment(ctx, u.TeamName, []string{u.UserID}, candidateIDs)
+		if err != nil {
+			return err
+		}
+		if err := s.applyReassignment(ctx, []string{u.UserID}, changes); err != nil {
 			return err
 		}
 
-		if pr.Status != prdomain.PRStatusOpen {
-			continue
+		for _, c := range changes {
+			reassigned = append(reassigned, c.PullRequestID)
+		}
+		return nil
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to add absence",
+				zap.String("user_id", a.UserID),
+				zap.Error(err),
+			)
+		}
+		return nil, nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("absence added",
+			zap.String("user_id", a.UserID),
+			zap.Int64("absence_id", a.AbsenceID),
+			zap.Time("starts_at", a.StartsAt),
+			zap.Time("ends_at", a.EndsAt),
+			zap.Strings("reassigned_prs", reassigned),
+		)
+	}
+
+	return &a, reassigned, nil
+}
+
+func (s *Service) ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		if s.logger != nil {
+			s.logger.Error("user not found when listing absences",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	absences, err := s.users.ListAbsences(ctx, userID)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list absences",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	return absences, nil
+}
+
+// UpdateAbsence changes the period and reason of an absence; the owner of
+// the absence cannot be changed.
+func (s *Service) UpdateAbsence(ctx context.Context, a domain.Absence) (*domain.Absence, error) {
+	a.StartsAt = a.StartsAt.UTC()
+	a.EndsAt = a.EndsAt.UTC()
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.users.GetAbsence(ctx, a.AbsenceID)
+		if err != nil {
+			return err
+		}
+
+		a.UserID = existing.UserID
+		if err := a.Validate(); err != nil {
+			return err
+		}
+
+		return s.users.UpdateAbsence(ctx, a)
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to update absence",
+				zap.Int64("absence_id", a.AbsenceID),
+				zap.Error(err),
+			)
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("absence updated",
+			zap.Int64("absence_id", a.AbsenceID),
+			zap.String("user_id", a.UserID),
+		)
+	}
+
+	return &a, nil
+}
+
+func (s *Service) DeleteAbsence(ctx context.Context, id int64) error {
+	if err := s.users.DeleteAbsence(ctx, id); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to delete absence",
+				zap.Int64("absence_id", id),
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+
+	if s.logger != nil {
+		s.logger.Info("absence deleted", zap.Int64("absence_id", id))
+	}
+
+	return nil
+}
+
+// planReassignment works out how the leaving reviewers are replaced on their
+// open PRs by candidates that are not on leave right now and have not reached
+// their review limit. Each slot goes to one of the candidates that got the
+// fewest reviews so far in this run, chosen by the team's selector; the PR
+// author is never picked. Pull requests are loaded in bulk and nothing is
+// written; a slot stays empty when no candidate is left.
+func (s *Service) planReassignment(
+	ctx context.Context,
+	teamName string,
+	leaving []string,
+	candidateIDs []string,
+) ([]prdomain.ReviewerChange, error) {
+	prs, err := s.prs.ListOpenWithReviewers(ctx, leaving)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to list open PRs for leaving reviewers",
+				zap.Strings("reviewers", leaving),
+				zap.Error(err),
+			)
 		}
+		return nil, err
+	}
 
-		newReviewers := make([]string, 0, len(pr.AssignedReviewers))
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	avail, err := s.availability.Check(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	leavingSet := make(map[string]struct{}, len(leaving))
+	for _, id := range leaving {
+		leavingSet[id] = struct{}{}
+	}
+
+	var changes []prdomain.ReviewerChange
+
+	// picks counts the reviews handed to each candidate in this run.
+	picks := make(map[string]int, len(candidateIDs))
+
+	for i := range prs {
+		pr := &prs[i]
 
 		assignedSet := make(map[string]struct{}, len(pr.AssignedReviewers))
+		for _, rID := range pr.AssignedReviewers {
+			if _, isLeaving := leavingSet[rID]; !isLeaving {
+				assignedSet[rID] = struct{}{}
+			}
+		}
 
-		pickCandidate := func() (string, bool) {
+		pickCandidate := func() (string, error) {
+			var eligible []string
+			fewest := -1
 			for _, cid := range candidateIDs {
+				if cid == pr.AuthorID {
+					continue
+				}
 				if _, already := assignedSet[cid]; already {
 					continue
 				}
-				return cid, true
+				if !avail.Free(cid) {
+					continue
+				}
+				switch n := picks[cid]; {
+				case fewest == -1 || n < fewest:
+					fewest = n
+					eligible = append(eligible[:0], cid)
+				case n == fewest:
+					eligible = append(eligible, cid)
+				}
+			}
+			if len(eligible) == 0 {
+				return "", nil
+			}
+
+			picked, err := s.selector.Select(ctx, teamName, eligible, 1)
+			if err != nil {
+				if s.logger != nil {
+					s.logger.Error("failed to select replacement reviewer",
+						zap.String("team_name", teamName),
+						zap.String("pr_id", pr.PullRequestID),
+						zap.Error(err),
+					)
+				}
+				return "", err
 			}
-			return "", false
+			if len(picked) == 0 {
+				return "", nil
+			}
+			return picked[0], nil
 		}
 
-		for _, rID := range pr.AssignedReviewers {
-			if _, isDeactivated := deactivatedSet[rID]; !isDeactivated {
-				newReviewers = append(newReviewers, rID)
-				assignedSet[rID] = struct{}{}
+		change := prdomain.ReviewerChange{
+			PullRequestID: pr.PullRequestID,
+			OldReviewers:  slices.Clone(pr.AssignedReviewers),
+		}
+
+		for _, rID := range change.OldReviewers {
+			if _, isLeaving := leavingSet[rID]; !isLeaving {
 				continue
 			}
 
-			if len(candidateIDs) == 0 {
-				continue
+			rp := prdomain.ReviewerReplacement{
+				PullRequestID: pr.PullRequestID,
+				OldReviewerID: rID,
+			}
+			cid, err := pickCandidate()
+			if err != nil {
+				return nil, err
+			}
+			if cid != "" {
+				err = pr.ReplaceReviewer(rID, cid)
+			} else {
+				err = pr.RemoveReviewer(rID)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("reassign %s on %s: %w", rID, pr.PullRequestID, err)
 			}
 
-			if cid, ok := pickCandidate(); ok {
-				newReviewers = append(newReviewers, cid)
+			if cid != "" {
+				rp.NewReviewerID = cid
 				assignedSet[cid] = struct{}{}
+				avail.Assign(cid)
+				picks[cid]++
 			}
-		}
 
-		if err := s.prs.SetReviewers(ctx, pr.PullRequestID, newReviewers); err != nil {
-			if s.logger != nil {
-				s.logger.Error("failed to update reviewers on  deactivate",
-					zap.String("pr_id", pr.PullRequestID),
-					zap.Strings("new_reviewers", newReviewers),
-					zap.Error(err),
-				)
-			}
-			return err
+			change.Replacements = append(change.Replacements, rp)
 		}
+
+		change.NewReviewers = slices.Clone(pr.AssignedReviewers)
+		changes = append(changes, change)
 	}
 
-	for _, id := range toDeactivate {
-		if err := s.users.UpdateActive(ctx, id, false); err != nil {
-			if s.logger != nil {
-				s.logger.Error("failed to deactivate user after reassignment",
-					zap.String("user_id", id),
-					zap.Error(err),
-				)
-			}
-			return err
-		}
+	return changes, nil
+}
+
+// applyReassignment writes the planned changes with a single bulk update.
+func (s *Service) applyReassignment(ctx context.Context, leaving []string, changes []prdomain.ReviewerChange) error {
+	if len(changes) == 0 {
+		return nil
 	}
 
-	if s.logger != nil {
-		s.logger.Info(" deactivate & reassignment completed",
-			zap.String("team_name", teamName),
-			zap.Strings("deactivated", toDeactivate),
-		)
+	var replacements []prdomain.ReviewerReplacement
+	for _, c := range changes {
+		replacements = append(replacements, c.Replacements...)
+	}
+
+	if err := s.prs.ReplaceReviewers(ctx, replacements); err != nil {
+		if s.logger != nil {
+			s.logger.Error("failed to replace reviewers of leaving users",
+				zap.Strings("reviewers", leaving),
+				zap.Int("replacements", len(replacements)),
+				zap.Error(err),
+			)
+		}
+		return err
 	}
 
 	return nil
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+	prmem "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/infra/memory"
+	prpg "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/infra/postgres"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teammem "github.com/dunooo0ooo/avito-test-task/internal/team/infra/memory"
+	teampg "github.com/dunooo0ooo/avito-test-task/internal/team/infra/postgres"
+	"github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	usermem "github.com/dunooo0ooo/avito-test-task/internal/user/infra/memory"
+	userpg "github.com/dunooo0ooo/avito-test-task/internal/user/infra/postgres"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+	"github.com/dunooo0ooo/avito-test-task/pkg/pgtest"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
+)
+
+// deactivationBenchUsers is the team size the spec sets the 100 ms target for.
+const deactivationBenchUsers = 200
+
+type benchStorage struct {
+	users domain.UserRepository
+	prs   prdomain.PullRequestRepository
+	teams teamdomain.TeamRepository
+	tx    txmanager.TxManager
+}
+
+// BenchmarkService_DeactivateTeamUsersAndReassign deactivates half of a
+// 200-member team whose open pull requests are all reviewed by the leaving
+// half, so every call goes through ListOpenWithReviewers, ReplaceReviewers and
+// DeactivateUsers for 100 users and 100 pull requests. The postgres run needs
+// TEST_POSTGRES_DSN.
+func BenchmarkService_DeactivateTeamUsersAndReassign(b *testing.B) {
+	b.Run("memory", func(b *testing.B) {
+		benchmarkDeactivation(b, func(b *testing.B) benchStorage {
+			store := memstore.New()
+			return benchStorage{
+				users: usermem.NewUserRepository(store),
+				prs:   prmem.NewPullRequestRepository(store),
+				teams: teammem.NewTeamRepository(store),
+				tx:    store,
+			}
+		})
+	})
+
+	b.Run("postgres", func(b *testing.B) {
+		pool := pgtest.Pool(b)
+
+		benchmarkDeactivation(b, func(b *testing.B) benchStorage {
+			pgtest.Reset(b, pool)
+			return benchStorage{
+				users: userpg.NewUserRepository(pool),
+				prs:   prpg.NewPullRequestRepository(pool),
+				teams: teampg.NewTeamRepository(pool),
+				tx:    txmanager.NewPostgres(pool),
+			}
+		})
+	})
+}
+
+func benchmarkDeactivation(b *testing.B, newStorage func(b *testing.B) benchStorage) {
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		st := newStorage(b)
+		leaving := seedDeactivationBench(b, st)
+		svc := NewUserService(st.users, st.prs, st.tx, nil, nil)
+		b.StartTimer()
+
+		report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", leaving, false)
+		require.NoError(b, err)
+		require.Len(b, report.Deactivated, len(leaving))
+	}
+}
+
+// seedDeactivationBench creates the team and one open pull request per leaving
+// member, authored by a staying member and reviewed by two leaving ones.
+func seedDeactivationBench(b *testing.B, st benchStorage) []string {
+	b.Helper()
+	ctx := context.Background()
+
+	require.NoError(b, st.teams.Create(ctx, &teamdomain.Team{TeamName: "backend"}))
+
+	members := make([]domain.User, 0, deactivationBenchUsers)
+	leaving := make([]string, 0, deactivationBenchUsers/2)
+	for i := 0; i < deactivationBenchUsers; i++ {
+		id := fmt.Sprintf("u%03d", i)
+		members = append(members, domain.User{UserID: id, Username: id, IsActive: true})
+		if i%2 == 1 {
+			leaving = append(leaving, id)
+		}
+	}
+	require.NoError(b, st.users.AddTeamMembers(ctx, "backend", members))
+
+	for i, id := range leaving {
+		prID := fmt.Sprintf("pr-%03d", i)
+		require.NoError(b, st.prs.Create(ctx, &prdomain.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: prID,
+			AuthorID:        members[2*i].UserID,
+		}))
+		require.NoError(b, st.prs.SetReviewers(ctx, prID, []string{id, leaving[(i+1)%len(leaving)]}))
+	}
+
+	return leaving
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	prapp "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/application"
 	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
 	prmocks "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/mocks"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
@@ -23,7 +25,7 @@ func TestService_SetIsActive_Success(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 
 	ctx := context.Background()
 
@@ -60,7 +62,7 @@ func TestService_SetIsActive_UpdateFails(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 
 	ctx := context.Background()
 
@@ -82,7 +84,7 @@ func TestService_SetIsActive_GetAfterUpdateFails(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 
 	ctx := context.Background()
 
@@ -108,7 +110,7 @@ func TestService_GetUserReviews_Success(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 
 	ctx := context.Background()
 
@@ -146,7 +148,7 @@ func TestService_GetUserReviews_UserNotFound(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 
 	ctx := context.Background()
 
@@ -168,7 +170,7 @@ func TestService_GetUserReviews_ListByReviewerFails(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 
 	ctx := context.Background()
 
@@ -196,11 +198,12 @@ func TestService_DeactivateTeamUsersAndReassign_EmptyUserIDs(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 	ctx := context.Background()
 
-	err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{})
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{}, false)
 	require.NoError(t, err)
+	assert.Empty(t, report.Deactivated)
 }
 
 func TestService_DeactivateTeamUsersAndReassign_ListByTeamError(t *testing.T) {
@@ -211,7 +214,7 @@ func TestService_DeactivateTeamUsersAndReassign_ListByTeamError(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 	ctx := context.Background()
 
 	expectedErr := errors.New("db error")
@@ -220,9 +223,10 @@ func TestService_DeactivateTeamUsersAndReassign_ListByTeamError(t *testing.T) {
 		ListByTeam(gomock.Any(), "backend").
 		Return(nil, expectedErr)
 
-	err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"})
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, expectedErr))
+	assert.Nil(t, report)
 }
 
 func TestService_DeactivateTeamUsersAndReassign_Success(t *testing.T) {
@@ -233,7 +237,7 @@ func TestService_DeactivateTeamUsersAndReassign_Success(t *testing.T) {
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 	ctx := context.Background()
 
 	members := []*userdomain.User{
@@ -242,47 +246,85 @@ func TestService_DeactivateTeamUsersAndReassign_Success(t *testing.T) {
 		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
 	}
 
-	shorts := []prdomain.PullRequestShort{
+	open := []prdomain.PullRequest{
 		{
-			PullRequestID:   "pr-1",
-			PullRequestName: "Add search",
-			AuthorID:        "u0",
-			Status:          prdomain.PRStatusOpen,
+			PullRequestID:     "pr-1",
+			PullRequestName:   "Add search",
+			AuthorID:          "u0",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u1", "u2"},
 		},
 	}
 
-	fullPR := &prdomain.PullRequest{
-		PullRequestID:     "pr-1",
-		PullRequestName:   "Add search",
-		AuthorID:          "u0",
-		Status:            prdomain.PRStatusOpen,
-		AssignedReviewers: []string{"u2"},
-	}
-
 	gomock.InOrder(
 		userRepo.EXPECT().
 			ListByTeam(gomock.Any(), "backend").
 			Return(members, nil),
 
 		prRepo.EXPECT().
-			ListOpenByReviewers(gomock.Any(), []string{"u2"}).
-			Return(shorts, nil),
+			ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+			Return(open, nil),
 
-		prRepo.EXPECT().
-			GetByID(gomock.Any(), "pr-1").
-			Return(fullPR, nil),
+		userRepo.EXPECT().
+			ListAbsent(gomock.Any(), []string{"u1", "u3"}, gomock.Any()).
+			Return(nil, nil),
+
+		userRepo.EXPECT().
+			ListReviewLimits(gomock.Any(), []string{"u1", "u3"}).
+			Return(nil, nil),
 
 		prRepo.EXPECT().
-			SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+			ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+				{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u3"},
+			}).
 			Return(nil),
 
 		userRepo.EXPECT().
-			UpdateActive(gomock.Any(), "u2", false).
+			DeactivateUsers(gomock.Any(), []string{"u2"}).
 			Return(nil),
 	)
 
-	err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"})
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, report.Deactivated)
+	require.Len(t, report.PullRequests, 1)
+	assert.Equal(t, []string{"u1", "u2"}, report.PullRequests[0].OldReviewers)
+	assert.Equal(t, []string{"u1", "u3"}, report.PullRequests[0].NewReviewers)
+}
+
+func TestService_DeactivateTeamUsersAndReassign_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}
+
+	userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil)
+	prRepo.EXPECT().
+		ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+		Return([]prdomain.PullRequest{{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u0",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1"}).Return(nil, nil)
+
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, true)
 	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []string{"u2"}, report.Deactivated)
+	require.Len(t, report.PullRequests, 1)
+	assert.Equal(t, []string{"u1"}, report.PullRequests[0].NewReviewers)
 }
 
 func TestService_DeactivateTeamUsersAndReassign_NoMatchingActiveUsers(t *testing.T) {
@@ -293,7 +335,7 @@ func TestService_DeactivateTeamUsersAndReassign_NoMatchingActiveUsers(t *testing
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 	ctx := context.Background()
 
 	members := []*userdomain.User{
@@ -305,11 +347,17 @@ func TestService_DeactivateTeamUsersAndReassign_NoMatchingActiveUsers(t *testing
 		ListByTeam(gomock.Any(), "backend").
 		Return(members, nil)
 
-	err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u3"})
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u3", "u2", "u3"}, false)
 	require.NoError(t, err)
+	assert.Empty(t, report.Deactivated)
+	assert.Empty(t, report.PullRequests)
+	assert.Equal(t, []userdomain.SkippedUser{
+		{UserID: "u3", Reason: userdomain.SkipReasonNotInTeam},
+		{UserID: "u2", Reason: userdomain.SkipReasonAlreadyInactive},
+	}, report.Skipped)
 }
 
-func TestService_DeactivateTeamUsersAndReassign_UpdateActiveError(t *testing.T) {
+func TestService_DeactivateTeamUsersAndReassign_DeactivateError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -317,57 +365,679 @@ func TestService_DeactivateTeamUsersAndReassign_UpdateActiveError(t *testing.T)
 	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
 	logger := zap.NewNop()
 
-	svc := NewUserService(userRepo, prRepo, logger)
+	svc := NewUserService(userRepo, prRepo, nil, nil, logger)
 	ctx := context.Background()
 
 	members := []*userdomain.User{
 		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
 		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
-		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
 	}
 
-	shorts := []prdomain.PullRequestShort{
+	updateErr := errors.New("update active failed")
+
+	gomock.InOrder(
+		userRepo.EXPECT().
+			ListByTeam(gomock.Any(), "backend").
+			Return(members, nil),
+
+		prRepo.EXPECT().
+			ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+			Return(nil, nil),
+
+		userRepo.EXPECT().
+			DeactivateUsers(gomock.Any(), []string{"u2"}).
+			Return(updateErr),
+	)
+
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, updateErr))
+	assert.Nil(t, report)
+}
+
+func TestService_DeactivateTeamUsersAndReassign_ReplaceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}
+
+	replaceErr := errors.New("replace failed")
+
+	userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil)
+	prRepo.EXPECT().
+		ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+		Return([]prdomain.PullRequest{{
+			PullRequestID:     "pr-1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1"}).Return(nil, nil)
+	prRepo.EXPECT().ReplaceReviewers(gomock.Any(), gomock.Any()).Return(replaceErr)
+
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, replaceErr))
+	assert.Nil(t, report)
+}
+
+func TestService_DeactivateTeamUsersAndReassign_SkipsAbsentCandidates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+	}
+
+	userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil)
+	prRepo.EXPECT().
+		ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+		Return([]prdomain.PullRequest{{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u0",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}}, nil)
+	userRepo.EXPECT().
+		ListAbsent(gomock.Any(), []string{"u1", "u3"}, gomock.Any()).
+		Return([]string{"u1"}, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1", "u3"}).Return(nil, nil)
+	prRepo.EXPECT().
+		ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u3"},
+		}).
+		Return(nil)
+	userRepo.EXPECT().DeactivateUsers(gomock.Any(), []string{"u2"}).Return(nil)
+
+	_, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.NoError(t, err)
+}
+
+func TestService_AddAbsence_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	now := time.Now()
+	a, reassigned, err := svc.AddAbsence(context.Background(), userdomain.Absence{
+		UserID:   "u1",
+		StartsAt: now,
+		EndsAt:   now.Add(-time.Hour),
+	}, false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, userdomain.ErrInvalidAbsence))
+	assert.Nil(t, a)
+	assert.Nil(t, reassigned)
+}
+
+func TestService_AddAbsence_FutureLeaveDoesNotReassign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	starts := time.Now().Add(24 * time.Hour)
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	userRepo.EXPECT().
+		CreateAbsence(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, a *userdomain.Absence) error {
+			a.AbsenceID = 7
+			return nil
+		})
+
+	a, reassigned, err := svc.AddAbsence(context.Background(), userdomain.Absence{
+		UserID:   "u1",
+		StartsAt: starts,
+		EndsAt:   starts.Add(48 * time.Hour),
+		Reason:   "vacation",
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), a.AbsenceID)
+	assert.Empty(t, reassigned)
+}
+
+func TestService_AddAbsence_ReassignsOpenReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	now := time.Now()
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u2").
+		Return(&userdomain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	userRepo.EXPECT().CreateAbsence(gomock.Any(), gomock.Any()).Return(nil)
+	userRepo.EXPECT().
+		ListByTeam(gomock.Any(), "backend").
+		Return([]*userdomain.User{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "u2", TeamName: "backend", IsActive: true},
+			{UserID: "u3", TeamName: "backend", IsActive: false},
+		}, nil)
+	prRepo.EXPECT().
+		ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+		Return([]prdomain.PullRequest{{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u0",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1"}).Return(nil, nil)
+	prRepo.EXPECT().
+		ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u1"},
+		}).
+		Return(nil)
+
+	_, reassigned, err := svc.AddAbsence(context.Background(), userdomain.Absence{
+		UserID:   "u2",
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1"}, reassigned)
+}
+
+func TestService_UpdateAbsence_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	userRepo.EXPECT().GetAbsence(gomock.Any(), int64(3)).Return(nil, userdomain.ErrAbsenceNotFound)
+
+	now := time.Now()
+	a, err := svc.UpdateAbsence(context.Background(), userdomain.Absence{
+		AbsenceID: 3,
+		StartsAt:  now,
+		EndsAt:    now.Add(time.Hour),
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, userdomain.ErrAbsenceNotFound))
+	assert.Nil(t, a)
+}
+
+func TestService_DeactivateTeamUsersAndReassign_RespectsReviewLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, prapp.NewRoundRobinSelector(), zap.NewNop())
+	ctx := context.Background()
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+	}
+
+	userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil)
+	prRepo.EXPECT().
+		ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+		Return([]prdomain.PullRequest{
+			{PullRequestID: "pr-1", AuthorID: "u0", Status: prdomain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+			{PullRequestID: "pr-2", AuthorID: "u0", Status: prdomain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+			{PullRequestID: "pr-3", AuthorID: "u0", Status: prdomain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+		}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1", "u3"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().
+		ListReviewLimits(gomock.Any(), []string{"u1", "u3"}).
+		Return(userdomain.ReviewLimits{"u1": 1, "u3": 2}, nil)
+	prRepo.EXPECT().
+		CountOpenByReviewers(gomock.Any(), []string{"u1", "u3"}).
+		Return(map[string]int64{"u1": 0, "u3": 1}, nil)
+	prRepo.EXPECT().
+		ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u1"},
+			{PullRequestID: "pr-2", OldReviewerID: "u2", NewReviewerID: "u3"},
+			{PullRequestID: "pr-3", OldReviewerID: "u2"},
+		}).
+		Return(nil)
+	userRepo.EXPECT().DeactivateUsers(gomock.Any(), []string{"u2"}).Return(nil)
+
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.NoError(t, err)
+	require.Len(t, report.PullRequests, 3)
+	assert.Empty(t, report.PullRequests[2].NewReviewers)
+}
+
+func TestService_SetMaxOpenReviews_Negative(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	err := svc.SetMaxOpenReviews(context.Background(), "u1", -1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, userdomain.ErrInvalidCapacity))
+}
+
+func TestService_DeactivateTeamUsersAndReassign_SpreadsReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+	}
+
+	open := make([]prdomain.PullRequest, 0, 4)
+	for _, id := range []string{"pr-1", "pr-2", "pr-3", "pr-4"} {
+		open = append(open, prdomain.PullRequest{
+			PullRequestID:     id,
+			AuthorID:          "u0",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		})
+	}
+
+	var got []prdomain.ReviewerReplacement
+
+	userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil)
+	prRepo.EXPECT().ListOpenWithReviewers(gomock.Any(), []string{"u2"}).Return(open, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1", "u3"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1", "u3"}).Return(nil, nil)
+	prRepo.EXPECT().
+		ReplaceReviewers(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, rs []prdomain.ReviewerReplacement) error {
+			got = rs
+			return nil
+		})
+	userRepo.EXPECT().DeactivateUsers(gomock.Any(), []string{"u2"}).Return(nil)
+
+	_, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.NoError(t, err)
+
+	perReviewer := make(map[string]int)
+	for _, r := range got {
+		perReviewer[r.NewReviewerID]++
+	}
+	assert.Equal(t, map[string]int{"u1": 2, "u3": 2}, perReviewer)
+}
+
+func TestService_DeactivateTeamUsersAndReassign_SkipsAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+	ctx := context.Background()
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}
+
+	userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil)
+	prRepo.EXPECT().
+		ListOpenWithReviewers(gomock.Any(), []string{"u2"}).
+		Return([]prdomain.PullRequest{{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}}, nil)
+	userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1"}, gomock.Any()).Return(nil, nil)
+	userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1"}).Return(nil, nil)
+	prRepo.EXPECT().
+		ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u2"},
+		}).
+		Return(nil)
+	userRepo.EXPECT().DeactivateUsers(gomock.Any(), []string{"u2"}).Return(nil)
+
+	report, err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"}, false)
+	require.NoError(t, err)
+	require.Len(t, report.PullRequests, 1)
+	assert.Equal(t, 1, report.PullRequests[0].EmptySlots())
+}
+
+func TestService_CreateUser_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	u, err := svc.CreateUser(context.Background(), userdomain.User{UserID: "u1", TeamName: "backend"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, userdomain.ErrInvalidUser))
+	assert.Nil(t, u)
+}
+
+func TestService_CreateUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	u := userdomain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	gomock.InOrder(
+		userRepo.EXPECT().Create(gomock.Any(), u).Return(nil),
+		userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(&u, nil),
+	)
+
+	created, err := svc.CreateUser(context.Background(), u)
+	require.NoError(t, err)
+	assert.Equal(t, &u, created)
+}
+
+func TestService_ListUsers_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	userRepo.EXPECT().
+		List(gomock.Any(), userdomain.ListFilter{TeamName: "backend", Limit: 3}).
+		Return([]userdomain.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}}, nil)
+
+	page, err := svc.ListUsers(context.Background(), userdomain.ListFilter{TeamName: "backend", Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, page.Users, 2)
+	assert.Equal(t, "u2", page.NextCursor)
+}
+
+func TestService_ListUsers_DefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	userRepo.EXPECT().
+		List(gomock.Any(), userdomain.ListFilter{Limit: defaultListLimit + 1}).
+		Return([]userdomain.User{{UserID: "u1"}}, nil)
+
+	page, err := svc.ListUsers(context.Background(), userdomain.ListFilter{})
+	require.NoError(t, err)
+	assert.Len(t, page.Users, 1)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestService_DeleteUser_HasOpenPRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	prRepo.EXPECT().
+		List(gomock.Any(), prdomain.ListFilter{AuthorID: "u1", Status: prdomain.PRStatusOpen, Limit: 1}).
+		Return([]prdomain.PullRequest{{PullRequestID: "pr-1", AuthorID: "u1"}}, nil)
+
+	changes, err := svc.DeleteUser(context.Background(), "u1")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, userdomain.ErrUserHasOpenPRs))
+	assert.Nil(t, changes)
+}
+
+func TestService_DeleteUser_HandsOverReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: false},
+	}
+
+	open := []prdomain.PullRequest{
 		{
-			PullRequestID:   "pr-1",
-			PullRequestName: "Add search",
-			AuthorID:        "u0",
-			Status:          prdomain.PRStatusOpen,
+			PullRequestID:     "pr-1",
+			AuthorID:          "u9",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u1"},
 		},
 	}
 
-	fullPR := &prdomain.PullRequest{
-		PullRequestID:     "pr-1",
-		PullRequestName:   "Add search",
-		AuthorID:          "u0",
-		Status:            prdomain.PRStatusOpen,
-		AssignedReviewers: []string{"u2"},
-	}
+	gomock.InOrder(
+		userRepo.EXPECT().GetByID(gomock.Any(), "u1").Return(members[0], nil),
+		prRepo.EXPECT().
+			List(gomock.Any(), prdomain.ListFilter{AuthorID: "u1", Status: prdomain.PRStatusOpen, Limit: 1}).
+			Return(nil, nil),
+		prRepo.EXPECT().
+			List(gomock.Any(), prdomain.ListFilter{AuthorID: "u1", Status: prdomain.PRStatusDraft, Limit: 1}).
+			Return(nil, nil),
+		userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil),
+		prRepo.EXPECT().ListOpenWithReviewers(gomock.Any(), []string{"u1"}).Return(open, nil),
+		userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u2"}, gomock.Any()).Return(nil, nil),
+		userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u2"}).Return(nil, nil),
+		prRepo.EXPECT().
+			ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+			}).
+			Return(nil),
+		userRepo.EXPECT().Delete(gomock.Any(), "u1").Return(nil),
+	)
 
-	updateErr := errors.New("update active failed")
+	changes, err := svc.DeleteUser(context.Background(), "u1")
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, []string{"u2"}, changes[0].NewReviewers)
+}
+
+func TestService_MoveTeam_AlreadyInTeam(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	userRepo.EXPECT().
+		GetByID(gomock.Any(), "u1").
+		Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+
+	res, err := svc.MoveTeam(context.Background(), "u1", "backend", true)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, userdomain.ErrAlreadyInTeam))
+	assert.Nil(t, res)
+}
+
+func TestService_MoveTeam_KeepsReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
 
 	gomock.InOrder(
 		userRepo.EXPECT().
-			ListByTeam(gomock.Any(), "backend").
-			Return(members, nil),
+			GetByID(gomock.Any(), "u1").
+			Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil),
+		userRepo.EXPECT().
+			MoveToTeam(gomock.Any(), &userdomain.TeamMove{UserID: "u1", FromTeam: "backend", ToTeam: "frontend"}).
+			Return(nil),
+	)
 
-		prRepo.EXPECT().
-			ListOpenByReviewers(gomock.Any(), []string{"u2"}).
-			Return(shorts, nil),
+	res, err := svc.MoveTeam(context.Background(), "u1", "frontend", false)
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", res.User.TeamName)
+	assert.Equal(t, "backend", res.Move.FromTeam)
+	assert.Empty(t, res.PullRequests)
+}
+
+func TestService_MoveTeam_HandsOverReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	open := []prdomain.PullRequest{
+		{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u3",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u1"},
+		},
+	}
+
+	gomock.InOrder(
+		userRepo.EXPECT().
+			GetByID(gomock.Any(), "u1").
+			Return(&userdomain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil),
+		userRepo.EXPECT().
+			MoveToTeam(gomock.Any(), gomock.Any()).
+			Return(nil),
+		userRepo.EXPECT().
+			ListByTeam(gomock.Any(), "backend").
+			Return([]*userdomain.User{
+				{UserID: "u2", TeamName: "backend", IsActive: true},
+				{UserID: "u3", TeamName: "backend", IsActive: true},
+			}, nil),
+		prRepo.EXPECT().ListOpenWithReviewers(gomock.Any(), []string{"u1"}).Return(open, nil),
+		userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u2", "u3"}, gomock.Any()).Return(nil, nil),
+		userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u2", "u3"}).Return(nil, nil),
 		prRepo.EXPECT().
-			GetByID(gomock.Any(), "pr-1").
-			Return(fullPR, nil),
+			ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+			}).
+			Return(nil),
+	)
+
+	res, err := svc.MoveTeam(context.Background(), "u1", "frontend", true)
+	require.NoError(t, err)
+	require.Len(t, res.PullRequests, 1)
+	assert.Equal(t, []string{"u2"}, res.PullRequests[0].NewReviewers)
+}
+
+func TestService_RemoveTeamMembers_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
+
+	members := []*userdomain.User{
+		{UserID: "u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+	}
 
+	open := []prdomain.PullRequest{
+		{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u9",
+			Status:            prdomain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		},
+	}
+
+	gomock.InOrder(
+		userRepo.EXPECT().ListByTeam(gomock.Any(), "backend").Return(members, nil),
+		prRepo.EXPECT().ListOpenWithReviewers(gomock.Any(), []string{"u2"}).Return(open, nil),
+		userRepo.EXPECT().ListAbsent(gomock.Any(), []string{"u1"}, gomock.Any()).Return(nil, nil),
+		userRepo.EXPECT().ListReviewLimits(gomock.Any(), []string{"u1"}).Return(nil, nil),
 		prRepo.EXPECT().
-			SetReviewers(gomock.Any(), "pr-1", gomock.Any()).
+			ReplaceReviewers(gomock.Any(), []prdomain.ReviewerReplacement{
+				{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u1"},
+			}).
 			Return(nil),
+		userRepo.EXPECT().RemoveFromTeam(gomock.Any(), "backend", []string{"u2"}).Return(nil),
+	)
+
+	report, err := svc.RemoveTeamMembers(context.Background(), "backend", []string{"u2", "u9", "u9"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, report.Removed)
+	assert.Equal(t, []userdomain.SkippedUser{{UserID: "u9", Reason: userdomain.SkipReasonNotInTeam}}, report.Skipped)
+	require.Len(t, report.PullRequests, 1)
+}
+
+func TestService_RemoveTeamMembers_KeepsAuthoredPRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userRepo := usermocks.NewMockUserRepository(ctrl)
+	prRepo := prmocks.NewMockPullRequestRepository(ctrl)
+
+	svc := NewUserService(userRepo, prRepo, nil, nil, zap.NewNop())
 
+	gomock.InOrder(
 		userRepo.EXPECT().
-			UpdateActive(gomock.Any(), "u2", false).
-			Return(updateErr),
+			ListByTeam(gomock.Any(), "backend").
+			Return([]*userdomain.User{{UserID: "u1", TeamName: "backend", IsActive: true}}, nil),
+		prRepo.EXPECT().ListOpenWithReviewers(gomock.Any(), []string{"u1"}).Return(nil, nil),
+		userRepo.EXPECT().RemoveFromTeam(gomock.Any(), "backend", []string{"u1"}).Return(nil),
 	)
 
-	err := svc.DeactivateTeamUsersAndReassign(ctx, "backend", []string{"u2"})
-	require.Error(t, err)
-	assert.True(t, errors.Is(err, updateErr))
+	report, err := svc.RemoveTeamMembers(context.Background(), "backend", []string{"u1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, report.Removed)
+	assert.Empty(t, report.PullRequests)
 }
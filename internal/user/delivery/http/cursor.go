@@ -0,0 +1,24 @@
+package http
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor makes an opaque page token out of the last user's user_id.
+func encodeCursor(userID string) string {
+	if userID == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(userID))
+}
+
+func decodeCursor(token string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) == 0 {
+		return "", errInvalidCursor
+	}
+	return string(raw), nil
+}
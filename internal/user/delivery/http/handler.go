@@ -10,12 +10,26 @@ import (
 	"github.com/dunooo0ooo/avito-test-task/internal/user/domain"
 	"github.com/dunooo0ooo/avito-test-task/pkg/httpcommon"
 	"net/http"
+	"strconv"
 )
 
 type UserService interface {
+	CreateUser(ctx context.Context, u domain.User) (*domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	UpdateUsername(ctx context.Context, userID, username string) (*domain.User, error)
+	ListUsers(ctx context.Context, filter domain.ListFilter) (*domain.UserPage, error)
+	DeleteUser(ctx context.Context, userID string) ([]pr.ReviewerChange, error)
+	MoveTeam(ctx context.Context, userID, toTeam string, reassign bool) (*domain.TeamMoveResult, error)
+	ListTeamMoves(ctx context.Context, userID string) ([]domain.TeamMove, error)
 	SetIsActive(ctx context.Context, userID string, active bool) (*domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit int) error
 	GetUserReviews(ctx context.Context, userID string) (*pr.UserReviews, error)
-	DeactivateTeamUsersAndReassign(ctx context.Context, teamName string, userIDs []string) error
+	DeactivateTeamUsersAndReassign(ctx context.Context, teamName string, userIDs []string, dryRun bool) (*domain.DeactivationReport, error)
+	RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string) (*domain.RemovalReport, error)
+	AddAbsence(ctx context.Context, a domain.Absence, reassign bool) (*domain.Absence, []string, error)
+	ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error)
+	UpdateAbsence(ctx context.Context, a domain.Absence) (*domain.Absence, error)
+	DeleteAbsence(ctx context.Context, id int64) error
 }
 
 type UserHandler struct {
@@ -29,9 +43,249 @@ func NewUserHandler(userService UserService) *UserHandler {
 }
 
 func (h *UserHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /users/create", h.CreateUser)
+	mux.HandleFunc("GET /users/get", h.GetUser)
+	mux.HandleFunc("POST /users/update", h.UpdateUser)
+	mux.HandleFunc("GET /users/list", h.ListUsers)
+	mux.HandleFunc("POST /users/delete", h.DeleteUser)
+	mux.HandleFunc("POST /users/moveTeam", h.MoveTeam)
+	mux.HandleFunc("GET /users/teamMoves", h.ListTeamMoves)
 	mux.HandleFunc("POST /users/setIsActive", h.SetIsActive)
+	mux.HandleFunc("POST /users/setMaxOpenReviews", h.SetMaxOpenReviews)
 	mux.HandleFunc("GET /users/getReview", h.GetUserReviews)
 	mux.HandleFunc("POST /team/deactivateMembers", h.BulkDeactivate)
+	mux.HandleFunc("POST /team/removeMembers", h.RemoveTeamMembers)
+	mux.HandleFunc("POST /users/absences/add", h.AddAbsence)
+	mux.HandleFunc("GET /users/absences", h.ListAbsences)
+	mux.HandleFunc("POST /users/absences/update", h.UpdateAbsence)
+	mux.HandleFunc("POST /users/absences/delete", h.DeleteAbsence)
+}
+
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	u := domain.User{
+		UserID:   req.UserID,
+		Username: req.Username,
+		TeamName: req.TeamName,
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+
+	created, err := h.userService.CreateUser(r.Context(), u)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusCreated, UserResponse{User: toUserDTO(created)})
+}
+
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	u, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, UserResponse{User: toUserDTO(u)})
+}
+
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var req UpdateUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	u, err := h.userService.UpdateUsername(r.Context(), req.UserID, req.Username)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, UserResponse{User: toUserDTO(u)})
+}
+
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListFilter(r)
+	if err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	page, err := h.userService.ListUsers(r.Context(), filter)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	resp := ListUsersResponse{
+		Users:      make([]UserDTO, 0, len(page.Users)),
+		NextCursor: encodeCursor(page.NextCursor),
+	}
+	for i := range page.Users {
+		resp.Users = append(resp.Users, toUserDTO(&page.Users[i]))
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func parseListFilter(r *http.Request) (domain.ListFilter, error) {
+	q := r.URL.Query()
+
+	filter := domain.ListFilter{
+		TeamName: q.Get("team_name"),
+	}
+
+	if v := q.Get("is_active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("is_active must be a boolean")
+		}
+		filter.IsActive = &active
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return filter, errors.New("limit must be a positive integer")
+		}
+		filter.Limit = n
+	}
+	if v := q.Get("cursor"); v != "" {
+		after, err := decodeCursor(v)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = after
+	}
+
+	return filter, nil
+}
+
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	var req DeleteUserRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	changes, err := h.userService.DeleteUser(r.Context(), req.UserID)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, DeleteUserResponse{
+		UserID:       req.UserID,
+		PullRequests: pr_http.ToReviewerChangeDTOs(changes),
+	})
+}
+
+func (h *UserHandler) MoveTeam(w http.ResponseWriter, r *http.Request) {
+	var req MoveTeamRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.UserID == "" || req.TeamName == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id and team_name are required")
+		return
+	}
+
+	res, err := h.userService.MoveTeam(r.Context(), req.UserID, req.TeamName, req.ReassignReviews)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, MoveTeamResponse{
+		User:         toUserDTO(&res.User),
+		Move:         toTeamMoveDTO(res.Move),
+		PullRequests: pr_http.ToReviewerChangeDTOs(res.PullRequests),
+	})
+}
+
+func (h *UserHandler) ListTeamMoves(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	moves, err := h.userService.ListTeamMoves(r.Context(), userID)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	resp := ListTeamMovesResponse{
+		UserID: userID,
+		Moves:  make([]TeamMoveDTO, 0, len(moves)),
+	}
+	for _, m := range moves {
+		resp.Moves = append(resp.Moves, toTeamMoveDTO(m))
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func toTeamMoveDTO(m domain.TeamMove) TeamMoveDTO {
+	return TeamMoveDTO{
+		MoveID:   m.MoveID,
+		FromTeam: m.FromTeam,
+		ToTeam:   m.ToTeam,
+		MovedAt:  m.MovedAt,
+	}
+}
+
+func writeUserError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+	case errors.Is(err, teamdomain.ErrTeamNotFound):
+		httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+	case errors.Is(err, domain.ErrUserExists):
+		httpcommon.JSONError(w, http.StatusConflict, "USER_EXISTS", "user_id already exists")
+	case errors.Is(err, domain.ErrAlreadyInTeam):
+		httpcommon.JSONError(w, http.StatusConflict, "ALREADY_IN_TEAM", err.Error())
+	case errors.Is(err, domain.ErrUserHasOpenPRs):
+		httpcommon.JSONError(w, http.StatusConflict, "USER_HAS_OPEN_PRS", err.Error())
+	case errors.Is(err, domain.ErrInvalidUser):
+		httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_USER", err.Error())
+	default:
+		httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+	}
+}
+
+func toUserDTO(u *domain.User) UserDTO {
+	return UserDTO{
+		UserID:   u.UserID,
+		Username: u.Username,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+	}
 }
 
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +321,38 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	httpcommon.JSONResponse(w, http.StatusOK, resp)
 }
 
+func (h *UserHandler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req SetMaxOpenReviewsRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	if err := h.userService.SetMaxOpenReviews(r.Context(), req.UserID, req.MaxOpenReviews); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrInvalidCapacity):
+			httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_CAPACITY", err.Error())
+		default:
+			httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	resp := SetMaxOpenReviewsResponse{
+		UserID:         req.UserID,
+		MaxOpenReviews: req.MaxOpenReviews,
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
 func (h *UserHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
@@ -117,7 +403,8 @@ func (h *UserHandler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.userService.DeactivateTeamUsersAndReassign(r.Context(), req.TeamName, req.UserIDs); err != nil {
+	report, err := h.userService.DeactivateTeamUsersAndReassign(r.Context(), req.TeamName, req.UserIDs, req.DryRun)
+	if err != nil {
 		switch {
 		case errors.Is(err, teamdomain.ErrTeamNotFound):
 			httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
@@ -127,10 +414,184 @@ func (h *UserHandler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	httpcommon.JSONResponse(w, http.StatusOK, toDeactivateResponse(report))
+}
+
+func (h *UserHandler) RemoveTeamMembers(w http.ResponseWriter, r *http.Request) {
+	var req RemoveMembersRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.TeamName == "" || len(req.UserIDs) == 0 {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name and user_ids are required")
+		return
+	}
+
+	report, err := h.userService.RemoveTeamMembers(r.Context(), req.TeamName, req.UserIDs)
+	if err != nil {
+		writeUserError(w, err)
+		return
+	}
+
+	resp := RemoveMembersResponse{
+		TeamName:     report.TeamName,
+		Removed:      append([]string{}, report.Removed...),
+		Skipped:      make([]SkippedUserDTO, 0, len(report.Skipped)),
+		PullRequests: pr_http.ToReviewerChangeDTOs(report.PullRequests),
+	}
+	for _, sk := range report.Skipped {
+		resp.Skipped = append(resp.Skipped, SkippedUserDTO{
+			UserID: sk.UserID,
+			Reason: string(sk.Reason),
+		})
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, resp)
+}
+
+func toDeactivateResponse(report *domain.DeactivationReport) DeactivateResponse {
 	resp := DeactivateResponse{
-		TeamName:    req.TeamName,
-		Deactivated: req.UserIDs,
+		TeamName:     report.TeamName,
+		DryRun:       report.DryRun,
+		Deactivated:  make([]string, 0, len(report.Deactivated)),
+		Skipped:      make([]SkippedUserDTO, 0, len(report.Skipped)),
+		PullRequests: pr_http.ToReviewerChangeDTOs(report.PullRequests),
+	}
+
+	resp.Deactivated = append(resp.Deactivated, report.Deactivated...)
+
+	for _, sk := range report.Skipped {
+		resp.Skipped = append(resp.Skipped, SkippedUserDTO{
+			UserID: sk.UserID,
+			Reason: string(sk.Reason),
+		})
+	}
+
+	return resp
+}
+
+func (h *UserHandler) AddAbsence(w http.ResponseWriter, r *http.Request) {
+	var req AddAbsenceRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+
+	a, reassigned, err := h.userService.AddAbsence(r.Context(), domain.Absence{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	}, req.Reassign)
+	if err != nil {
+		writeAbsenceError(w, err)
+		return
+	}
+
+	if reassigned == nil {
+		reassigned = []string{}
+	}
+
+	resp := AddAbsenceResponse{
+		Absence:                toAbsenceDTO(*a),
+		ReassignedPullRequests: reassigned,
+	}
+
+	httpcommon.JSONResponse(w, http.StatusCreated, resp)
+}
+
+func (h *UserHandler) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	absences, err := h.userService.ListAbsences(r.Context(), userID)
+	if err != nil {
+		writeAbsenceError(w, err)
+		return
+	}
+
+	resp := ListAbsencesResponse{
+		UserID:   userID,
+		Absences: make([]AbsenceDTO, 0, len(absences)),
+	}
+	for _, a := range absences {
+		resp.Absences = append(resp.Absences, toAbsenceDTO(a))
 	}
 
 	httpcommon.JSONResponse(w, http.StatusOK, resp)
 }
+
+func (h *UserHandler) UpdateAbsence(w http.ResponseWriter, r *http.Request) {
+	var req UpdateAbsenceRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.AbsenceID == 0 {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "absence_id is required")
+		return
+	}
+
+	a, err := h.userService.UpdateAbsence(r.Context(), domain.Absence{
+		AbsenceID: req.AbsenceID,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		writeAbsenceError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, AbsenceResponse{Absence: toAbsenceDTO(*a)})
+}
+
+func (h *UserHandler) DeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	var req DeleteAbsenceRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		return
+	}
+	if req.AbsenceID == 0 {
+		httpcommon.JSONError(w, http.StatusBadRequest, "BAD_REQUEST", "absence_id is required")
+		return
+	}
+
+	if err := h.userService.DeleteAbsence(r.Context(), req.AbsenceID); err != nil {
+		writeAbsenceError(w, err)
+		return
+	}
+
+	httpcommon.JSONResponse(w, http.StatusOK, DeleteAbsenceResponse{AbsenceID: req.AbsenceID})
+}
+
+func writeAbsenceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+	case errors.Is(err, domain.ErrAbsenceNotFound):
+		httpcommon.JSONError(w, http.StatusNotFound, "NOT_FOUND", "absence not found")
+	case errors.Is(err, domain.ErrInvalidAbsence):
+		httpcommon.JSONError(w, http.StatusBadRequest, "INVALID_ABSENCE", err.Error())
+	default:
+		httpcommon.JSONError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+	}
+}
+
+func toAbsenceDTO(a domain.Absence) AbsenceDTO {
+	return AbsenceDTO{
+		AbsenceID: a.AbsenceID,
+		UserID:    a.UserID,
+		StartsAt:  a.StartsAt,
+		EndsAt:    a.EndsAt,
+		Reason:    a.Reason,
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	pr_http "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
 	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
 	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
 	userdomain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
@@ -15,6 +16,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type errorResponse struct {
@@ -348,8 +350,18 @@ func TestUserHandler_BulkDeactivate_Success(t *testing.T) {
 	}`
 
 	svc.EXPECT().
-		DeactivateTeamUsersAndReassign(gomock.Any(), "backend", []string{"u2", "u3"}).
-		Return(nil)
+		DeactivateTeamUsersAndReassign(gomock.Any(), "backend", []string{"u2", "u3"}, false).
+		Return(&userdomain.DeactivationReport{
+			TeamName:    "backend",
+			Deactivated: []string{"u2"},
+			Skipped:     []userdomain.SkippedUser{{UserID: "u3", Reason: userdomain.SkipReasonAlreadyInactive}},
+			PullRequests: []prdomain.ReviewerChange{{
+				PullRequestID: "pr-1",
+				OldReviewers:  []string{"u1", "u2"},
+				NewReviewers:  []string{"u1"},
+				Replacements:  []prdomain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "u2"}},
+			}},
+		}, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/team/deactivateMembers", strings.NewReader(body))
 	w := httptest.NewRecorder()
@@ -367,7 +379,13 @@ func TestUserHandler_BulkDeactivate_Success(t *testing.T) {
 	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
 
 	assert.Equal(t, "backend", resp.TeamName)
-	assert.ElementsMatch(t, []string{"u2", "u3"}, resp.Deactivated)
+	assert.Equal(t, []string{"u2"}, resp.Deactivated)
+	assert.Equal(t, []SkippedUserDTO{{UserID: "u3", Reason: "ALREADY_INACTIVE"}}, resp.Skipped)
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, []string{"u1", "u2"}, resp.PullRequests[0].OldReviewers)
+	assert.Equal(t, []string{"u1"}, resp.PullRequests[0].NewReviewers)
+	assert.Equal(t, []pr_http.ReviewerReplacementDTO{{OldReviewerID: "u2"}}, resp.PullRequests[0].Replacements)
+	assert.Equal(t, 1, resp.PullRequests[0].EmptySlots)
 }
 
 func TestUserHandler_BulkDeactivate_InvalidBody(t *testing.T) {
@@ -463,8 +481,8 @@ func TestUserHandler_BulkDeactivate_TeamNotFound(t *testing.T) {
 	}`
 
 	svc.EXPECT().
-		DeactivateTeamUsersAndReassign(gomock.Any(), "unknown", []string{"u2"}).
-		Return(teamdomain.ErrTeamNotFound)
+		DeactivateTeamUsersAndReassign(gomock.Any(), "unknown", []string{"u2"}, false).
+		Return(nil, teamdomain.ErrTeamNotFound)
 
 	req := httptest.NewRequest(http.MethodPost, "/team/deactivateMembers", strings.NewReader(body))
 	w := httptest.NewRecorder()
@@ -484,3 +502,524 @@ func TestUserHandler_BulkDeactivate_TeamNotFound(t *testing.T) {
 	assert.Equal(t, "NOT_FOUND", errResp.Error.Code)
 	assert.Equal(t, "team not found", errResp.Error.Message)
 }
+
+func TestUserHandler_AddAbsence_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	starts := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	ends := time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	svc.EXPECT().
+		AddAbsence(gomock.Any(), userdomain.Absence{
+			UserID:   "u1",
+			StartsAt: starts,
+			EndsAt:   ends,
+			Reason:   "vacation",
+		}, true).
+		Return(&userdomain.Absence{
+			AbsenceID: 5,
+			UserID:    "u1",
+			StartsAt:  starts,
+			EndsAt:    ends,
+			Reason:    "vacation",
+		}, []string{"pr-1"}, nil)
+
+	body := `{"user_id":"u1","starts_at":"2025-07-01T00:00:00Z","ends_at":"2025-07-15T00:00:00Z","reason":"vacation","reassign":true}`
+	req := httptest.NewRequest(http.MethodPost, "/users/absences/add", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.AddAbsence(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var resp AddAbsenceResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+
+	assert.Equal(t, int64(5), resp.Absence.AbsenceID)
+	assert.True(t, starts.Equal(resp.Absence.StartsAt))
+	assert.Equal(t, []string{"pr-1"}, resp.ReassignedPullRequests)
+}
+
+func TestUserHandler_AddAbsence_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		AddAbsence(gomock.Any(), gomock.Any(), false).
+		Return(nil, nil, userdomain.ErrInvalidAbsence)
+
+	body := `{"user_id":"u1","starts_at":"2025-07-15T00:00:00Z","ends_at":"2025-07-01T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/absences/add", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.AddAbsence(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var resp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "INVALID_ABSENCE", resp.Error.Code)
+}
+
+func TestUserHandler_ListAbsences_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		ListAbsences(gomock.Any(), "u1").
+		Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/absences?user_id=u1", nil)
+	w := httptest.NewRecorder()
+
+	h.ListAbsences(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ListAbsencesResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "u1", resp.UserID)
+	assert.NotNil(t, resp.Absences)
+	assert.Empty(t, resp.Absences)
+}
+
+func TestUserHandler_DeleteAbsence_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		DeleteAbsence(gomock.Any(), int64(9)).
+		Return(userdomain.ErrAbsenceNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/absences/delete", strings.NewReader(`{"absence_id":9}`))
+	w := httptest.NewRecorder()
+
+	h.DeleteAbsence(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	var resp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "NOT_FOUND", resp.Error.Code)
+}
+
+func TestUserHandler_SetMaxOpenReviews_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		SetMaxOpenReviews(gomock.Any(), "u1", 3).
+		Return(nil)
+
+	body := `{"user_id":"u1","max_open_reviews":3}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetMaxOpenReviews(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp SetMaxOpenReviewsResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "u1", resp.UserID)
+	assert.Equal(t, 3, resp.MaxOpenReviews)
+}
+
+func TestUserHandler_SetMaxOpenReviews_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		SetMaxOpenReviews(gomock.Any(), "u1", -1).
+		Return(userdomain.ErrInvalidCapacity)
+
+	body := `{"user_id":"u1","max_open_reviews":-1}`
+	req := httptest.NewRequest(http.MethodPost, "/users/setMaxOpenReviews", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.SetMaxOpenReviews(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var resp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "INVALID_CAPACITY", resp.Error.Code)
+}
+
+func TestUserHandler_CreateUser_DefaultsToActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	u := userdomain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+
+	svc.EXPECT().
+		CreateUser(gomock.Any(), u).
+		Return(&u, nil)
+
+	body := `{"user_id":"u1","username":"Alice","team_name":"backend"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.CreateUser(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var resp UserResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "u1", resp.User.UserID)
+	assert.True(t, resp.User.IsActive)
+}
+
+func TestUserHandler_CreateUser_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		CreateUser(gomock.Any(), gomock.Any()).
+		Return(nil, userdomain.ErrUserExists)
+
+	body := `{"user_id":"u1","username":"Alice","team_name":"backend","is_active":false}`
+	req := httptest.NewRequest(http.MethodPost, "/users/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.CreateUser(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "USER_EXISTS", errResp.Error.Code)
+}
+
+func TestUserHandler_GetUser_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		GetUser(gomock.Any(), "missing").
+		Return(nil, userdomain.ErrUserNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/get?user_id=missing", nil)
+	w := httptest.NewRecorder()
+
+	h.GetUser(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestUserHandler_ListUsers_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	active := true
+	svc.EXPECT().
+		ListUsers(gomock.Any(), userdomain.ListFilter{TeamName: "backend", IsActive: &active, After: "u1", Limit: 1}).
+		Return(&userdomain.UserPage{
+			Users:      []userdomain.User{{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}},
+			NextCursor: "u2",
+		}, nil)
+
+	url := "/users/list?team_name=backend&is_active=true&limit=1&cursor=" + encodeCursor("u1")
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	w := httptest.NewRecorder()
+
+	h.ListUsers(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp ListUsersResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	require.Len(t, resp.Users, 1)
+	assert.Equal(t, "u2", resp.Users[0].UserID)
+	assert.Equal(t, encodeCursor("u2"), resp.NextCursor)
+}
+
+func TestUserHandler_ListUsers_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	for _, query := range []string{"is_active=maybe", "limit=0", "cursor=!!"} {
+		req := httptest.NewRequest(http.MethodGet, "/users/list?"+query, nil)
+		w := httptest.NewRecorder()
+
+		h.ListUsers(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestUserHandler_DeleteUser_HasOpenPRs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		DeleteUser(gomock.Any(), "u1").
+		Return(nil, userdomain.ErrUserHasOpenPRs)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/delete", strings.NewReader(`{"user_id":"u1"}`))
+	w := httptest.NewRecorder()
+
+	h.DeleteUser(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var errResp errorResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+	assert.Equal(t, "USER_HAS_OPEN_PRS", errResp.Error.Code)
+}
+
+func TestUserHandler_DeleteUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		DeleteUser(gomock.Any(), "u1").
+		Return([]prdomain.ReviewerChange{{
+			PullRequestID: "pr-1",
+			OldReviewers:  []string{"u1"},
+			NewReviewers:  []string{"u2"},
+			Replacements:  []prdomain.ReviewerReplacement{{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"}},
+		}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/delete", strings.NewReader(`{"user_id":"u1"}`))
+	w := httptest.NewRecorder()
+
+	h.DeleteUser(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp DeleteUserResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "u1", resp.UserID)
+	require.Len(t, resp.PullRequests, 1)
+	assert.Equal(t, []string{"u2"}, resp.PullRequests[0].NewReviewers)
+}
+
+func TestUserHandler_MoveTeam_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	movedAt := time.Date(2025, 7, 1, 10, 0, 0, 0, time.UTC)
+	svc.EXPECT().
+		MoveTeam(gomock.Any(), "u1", "frontend", true).
+		Return(&userdomain.TeamMoveResult{
+			Move: userdomain.TeamMove{MoveID: 1, UserID: "u1", FromTeam: "backend", ToTeam: "frontend", MovedAt: movedAt},
+			User: userdomain.User{UserID: "u1", Username: "Alice", TeamName: "frontend", IsActive: true},
+		}, nil)
+
+	body := `{"user_id":"u1","team_name":"frontend","reassign_reviews":true}`
+	req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.MoveTeam(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp MoveTeamResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, "frontend", resp.User.TeamName)
+	assert.Equal(t, "backend", resp.Move.FromTeam)
+	assert.True(t, movedAt.Equal(resp.Move.MovedAt))
+	assert.NotNil(t, resp.PullRequests)
+}
+
+func TestUserHandler_MoveTeam_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"TeamNotFound", teamdomain.ErrTeamNotFound, http.StatusNotFound, "NOT_FOUND"},
+		{"AlreadyInTeam", userdomain.ErrAlreadyInTeam, http.StatusConflict, "ALREADY_IN_TEAM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := mocks.NewMockUserService(ctrl)
+			h := NewUserHandler(svc)
+
+			svc.EXPECT().
+				MoveTeam(gomock.Any(), "u1", "frontend", false).
+				Return(nil, tt.err)
+
+			body := `{"user_id":"u1","team_name":"frontend"}`
+			req := httptest.NewRequest(http.MethodPost, "/users/moveTeam", strings.NewReader(body))
+			w := httptest.NewRecorder()
+
+			h.MoveTeam(w, req)
+
+			res := w.Result()
+			defer func(Body io.ReadCloser) {
+				_ = Body.Close()
+			}(res.Body)
+
+			require.Equal(t, tt.wantStatus, res.StatusCode)
+
+			var errResp errorResponse
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&errResp))
+			assert.Equal(t, tt.wantCode, errResp.Error.Code)
+		})
+	}
+}
+
+func TestUserHandler_RemoveTeamMembers_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	svc.EXPECT().
+		RemoveTeamMembers(gomock.Any(), "backend", []string{"u2", "u9"}).
+		Return(&userdomain.RemovalReport{
+			TeamName: "backend",
+			Removed:  []string{"u2"},
+			Skipped:  []userdomain.SkippedUser{{UserID: "u9", Reason: userdomain.SkipReasonNotInTeam}},
+		}, nil)
+
+	body := `{"team_name":"backend","user_ids":["u2","u9"]}`
+	req := httptest.NewRequest(http.MethodPost, "/team/removeMembers", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	h.RemoveTeamMembers(w, req)
+
+	res := w.Result()
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(res.Body)
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var resp RemoveMembersResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+	assert.Equal(t, []string{"u2"}, resp.Removed)
+	assert.Equal(t, []SkippedUserDTO{{UserID: "u9", Reason: "NOT_IN_TEAM"}}, resp.Skipped)
+	assert.NotNil(t, resp.PullRequests)
+}
+
+func TestUserHandler_RemoveTeamMembers_MissingFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc := mocks.NewMockUserService(ctrl)
+	h := NewUserHandler(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/team/removeMembers", strings.NewReader(`{"team_name":"backend"}`))
+	w := httptest.NewRecorder()
+
+	h.RemoveTeamMembers(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
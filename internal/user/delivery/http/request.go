@@ -1,10 +1,17 @@
 package http
 
+import "time"
+
 type SetIsActiveRequest struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
 
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews int    `json:"max_open_reviews"`
+}
+
 type GetReviewsRequest struct {
 	UserID string `json:"user_id"`
 }
@@ -12,4 +19,51 @@ type GetReviewsRequest struct {
 type DeactivateRequest struct {
 	TeamName string   `json:"team_name"`
 	UserIDs  []string `json:"user_ids"`
+	DryRun   bool     `json:"dry_run"`
+}
+
+type AddAbsenceRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+	Reassign bool      `json:"reassign"`
+}
+
+type UpdateAbsenceRequest struct {
+	AbsenceID int64     `json:"absence_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason"`
+}
+
+type DeleteAbsenceRequest struct {
+	AbsenceID int64 `json:"absence_id"`
+}
+
+type CreateUserRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	TeamName string `json:"team_name"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type UpdateUserRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+type DeleteUserRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type MoveTeamRequest struct {
+	UserID          string `json:"user_id"`
+	TeamName        string `json:"team_name"`
+	ReassignReviews bool   `json:"reassign_reviews"`
+}
+
+type RemoveMembersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
 }
@@ -1,6 +1,10 @@
 package http
 
-import "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
+import (
+	"time"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/pullrequest/delivery/http"
+)
 
 type UserDTO struct {
 	UserID   string `json:"user_id"`
@@ -20,11 +24,89 @@ type SetIsActiveResponse struct {
 	User UserDTO `json:"user"`
 }
 
+type SetMaxOpenReviewsResponse struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews int    `json:"max_open_reviews"`
+}
+
 type GetReviewsResponse struct {
 	UserID       string                `json:"user_id"`
 	PullRequests []PullRequestShortDTO `json:"pull_requests"`
 }
+type SkippedUserDTO struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
 type DeactivateResponse struct {
-	TeamName    string   `json:"team_name"`
-	Deactivated []string `json:"deactivated"`
+	TeamName     string                   `json:"team_name"`
+	DryRun       bool                     `json:"dry_run"`
+	Deactivated  []string                 `json:"deactivated"`
+	Skipped      []SkippedUserDTO         `json:"skipped"`
+	PullRequests []http.ReviewerChangeDTO `json:"pull_requests"`
+}
+
+type AbsenceDTO struct {
+	AbsenceID int64     `json:"absence_id"`
+	UserID    string    `json:"user_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason"`
+}
+
+type AbsenceResponse struct {
+	Absence AbsenceDTO `json:"absence"`
+}
+
+type AddAbsenceResponse struct {
+	Absence                AbsenceDTO `json:"absence"`
+	ReassignedPullRequests []string   `json:"reassigned_pull_requests"`
+}
+
+type ListAbsencesResponse struct {
+	UserID   string       `json:"user_id"`
+	Absences []AbsenceDTO `json:"absences"`
+}
+
+type DeleteAbsenceResponse struct {
+	AbsenceID int64 `json:"absence_id"`
+}
+
+type UserResponse struct {
+	User UserDTO `json:"user"`
+}
+
+type ListUsersResponse struct {
+	Users      []UserDTO `json:"users"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+type DeleteUserResponse struct {
+	UserID       string                   `json:"user_id"`
+	PullRequests []http.ReviewerChangeDTO `json:"pull_requests"`
+}
+
+type TeamMoveDTO struct {
+	MoveID   int64     `json:"move_id"`
+	FromTeam string    `json:"from_team"`
+	ToTeam   string    `json:"to_team"`
+	MovedAt  time.Time `json:"moved_at"`
+}
+
+type MoveTeamResponse struct {
+	User         UserDTO                  `json:"user"`
+	Move         TeamMoveDTO              `json:"move"`
+	PullRequests []http.ReviewerChangeDTO `json:"pull_requests"`
+}
+
+type ListTeamMovesResponse struct {
+	UserID string        `json:"user_id"`
+	Moves  []TeamMoveDTO `json:"moves"`
+}
+
+type RemoveMembersResponse struct {
+	TeamName     string                   `json:"team_name"`
+	Removed      []string                 `json:"removed"`
+	Skipped      []SkippedUserDTO         `json:"skipped"`
+	PullRequests []http.ReviewerChangeDTO `json:"pull_requests"`
 }
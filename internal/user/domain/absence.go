@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Absence is a period when the user is on leave and must not get new reviews.
+// The period is half-open: StartsAt is included, EndsAt is not.
+type Absence struct {
+	AbsenceID int64
+	UserID    string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+}
+
+func (a Absence) Validate() error {
+	if a.UserID == "" {
+		return fmt.Errorf("%w: user_id is required", ErrInvalidAbsence)
+	}
+	if a.StartsAt.IsZero() || a.EndsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at and ends_at are required", ErrInvalidAbsence)
+	}
+	if !a.StartsAt.Before(a.EndsAt) {
+		return fmt.Errorf("%w: starts_at must be before ends_at", ErrInvalidAbsence)
+	}
+	return nil
+}
+
+func (a Absence) Covers(t time.Time) bool {
+	return !t.Before(a.StartsAt) && t.Before(a.EndsAt)
+}
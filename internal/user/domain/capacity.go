@@ -0,0 +1,13 @@
+package domain
+
+// ReviewLimits maps user_id to the maximum number of OPEN pull requests the user
+// may review at once. A user's own limit wins over the team default; users
+// without any limit are absent from the map.
+type ReviewLimits map[string]int
+
+// Reached reports whether a user with open reviews in progress can not take
+// another one.
+func (l ReviewLimits) Reached(userID string, open int64) bool {
+	limit, ok := l[userID]
+	return ok && open >= int64(limit)
+}
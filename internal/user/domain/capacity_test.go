@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewLimits_Reached(t *testing.T) {
+	limits := ReviewLimits{"u1": 2}
+
+	assert.False(t, limits.Reached("u1", 1))
+	assert.True(t, limits.Reached("u1", 2))
+	assert.True(t, limits.Reached("u1", 3))
+	assert.False(t, limits.Reached("u2", 100))
+}
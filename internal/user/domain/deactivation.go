@@ -0,0 +1,37 @@
+package domain
+
+import prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+
+// SkipReason explains why a requested user was not deactivated.
+type SkipReason string
+
+const (
+	SkipReasonNotInTeam       SkipReason = "NOT_IN_TEAM"
+	SkipReasonAlreadyInactive SkipReason = "ALREADY_INACTIVE"
+)
+
+// SkippedUser is a requested user that was left untouched.
+type SkippedUser struct {
+	UserID string
+	Reason SkipReason
+}
+
+// DeactivationReport describes a bulk deactivation: who was deactivated, who was
+// skipped and how the reviewers of their open pull requests were replaced.
+// A DryRun report describes the planned changes, none of which were written.
+type DeactivationReport struct {
+	TeamName     string
+	DryRun       bool
+	Deactivated  []string
+	Skipped      []SkippedUser
+	PullRequests []prdomain.ReviewerChange
+}
+
+// RemovalReport describes members removed from a team: who was removed, who was
+// skipped and how the reviewers of their open pull requests were replaced.
+type RemovalReport struct {
+	TeamName     string
+	Removed      []string
+	Skipped      []SkippedUser
+	PullRequests []prdomain.ReviewerChange
+}
@@ -5,4 +5,11 @@ import "errors"
 var (
 	ErrInternalDatabase = errors.New("user: internal database error")
 	ErrUserNotFound     = errors.New("user not found")
+	ErrUserExists       = errors.New("user already exists")
+	ErrInvalidUser      = errors.New("invalid user")
+	ErrUserHasOpenPRs   = errors.New("user has open pull requests")
+	ErrAlreadyInTeam    = errors.New("user is already in the team")
+	ErrAbsenceNotFound  = errors.New("absence not found")
+	ErrInvalidAbsence   = errors.New("invalid absence")
+	ErrInvalidCapacity  = errors.New("invalid review capacity")
 )
@@ -0,0 +1,15 @@
+package domain
+
+// ListFilter narrows List results; empty fields are not applied. After is the
+// user_id of the last user on the previous page, users are ordered by user_id.
+type ListFilter struct {
+	TeamName string
+	IsActive *bool
+	After    string
+	Limit    int
+}
+
+type UserPage struct {
+	Users      []User
+	NextCursor string
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	prdomain "github.com/dunooo0ooo/avito-test-task/internal/pullrequest/domain"
+)
+
+// TeamMove records that a user was moved from one team to another.
+type TeamMove struct {
+	MoveID   int64
+	UserID   string
+	FromTeam string
+	ToTeam   string
+	MovedAt  time.Time
+}
+
+// TeamMoveResult is what a move did: the recorded move, the user after it and
+// the open reviews handed over to the old team.
+type TeamMoveResult struct {
+	Move         TeamMove
+	User         User
+	PullRequests []prdomain.ReviewerChange
+}
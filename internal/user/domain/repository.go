@@ -1,11 +1,42 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type UserRepository interface {
+	// AddTeamMembers creates the users in the team or updates those already in it.
+	// Users without a team join it; users of another team or deleted ones are
+	// never taken over.
 	AddTeamMembers(ctx context.Context, teamName string, members []User) error
+	// Create adds a single user; a deleted user with the same ID is restored.
+	Create(ctx context.Context, u User) error
 	GetByID(ctx context.Context, id string) (*User, error)
+	List(ctx context.Context, filter ListFilter) ([]User, error)
+	UpdateUsername(ctx context.Context, id string, username string) error
+	// Delete hides the user from every lookup and deactivates them. The row is
+	// kept because pull requests and reviews keep referring to it.
+	Delete(ctx context.Context, id string) error
 	ListByTeam(ctx context.Context, teamName string) ([]*User, error)
+	// MoveToTeam changes the user's team to m.ToTeam and records the move,
+	// filling in MoveID and MovedAt.
+	MoveToTeam(ctx context.Context, m *TeamMove) error
+	// RemoveFromTeam leaves the given members of the team without a team. They
+	// stay in the system and can join a team again.
+	RemoveFromTeam(ctx context.Context, teamName string, ids []string) error
+	ListTeamMoves(ctx context.Context, userID string) ([]TeamMove, error)
 	UpdateActive(ctx context.Context, id string, active bool) error
 	DeactivateByTeam(ctx context.Context, teamName string) error
+	DeactivateUsers(ctx context.Context, ids []string) error
+	CreateAbsence(ctx context.Context, a *Absence) error
+	GetAbsence(ctx context.Context, id int64) (*Absence, error)
+	ListAbsences(ctx context.Context, userID string) ([]Absence, error)
+	UpdateAbsence(ctx context.Context, a Absence) error
+	DeleteAbsence(ctx context.Context, id int64) error
+	// ListAbsent returns those of userIDs that are on leave at the given moment.
+	ListAbsent(ctx context.Context, userIDs []string, at time.Time) ([]string, error)
+	// SetMaxOpenReviews sets the user's own limit; zero falls back to the team default.
+	SetMaxOpenReviews(ctx context.Context, id string, limit int) error
+	ListReviewLimits(ctx context.Context, userIDs []string) (ReviewLimits, error)
 }
@@ -0,0 +1,465 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Deps is what the suite needs from a storage backend. SeedTeam must create
+// an empty team so users can be attached to it.
+type Deps struct {
+	Users    domain.UserRepository
+	SeedTeam func(t *testing.T, teamName string)
+}
+
+// Run checks that a UserRepository behaves like the postgres one.
+// newDeps is called for every subtest and must return an empty storage.
+func Run(t *testing.T, newDeps func(t *testing.T) Deps) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, d Deps)
+	}{
+		{"AddAndGet", testAddAndGet},
+		{"GetByIDNotFound", testGetByIDNotFound},
+		{"AddTeamMembersUpserts", testAddTeamMembersUpserts},
+		{"ListByTeam", testListByTeam},
+		{"UpdateActive", testUpdateActive},
+		{"DeactivateByTeam", testDeactivateByTeam},
+		{"DeactivateUsers", testDeactivateUsers},
+		{"AbsenceCRUD", testAbsenceCRUD},
+		{"AbsenceNotFound", testAbsenceNotFound},
+		{"ListAbsent", testListAbsent},
+		{"ReviewLimits", testReviewLimits},
+		{"Create", testCreate},
+		{"List", testList},
+		{"UpdateUsername", testUpdateUsername},
+		{"Delete", testDelete},
+		{"MoveToTeam", testMoveToTeam},
+		{"RemoveFromTeam", testRemoveFromTeam},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newDeps(t))
+		})
+	}
+}
+
+func testAddAndGet(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+	}))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, u)
+}
+
+func testGetByIDNotFound(t *testing.T, d Deps) {
+	u, err := d.Users.GetByID(context.Background(), "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+	assert.Nil(t, u)
+}
+
+func testAddTeamMembersUpserts(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+	d.SeedTeam(t, "frontend")
+
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}))
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u1", Username: "Alice B.", IsActive: false},
+	}))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "u1", Username: "Alice B.", TeamName: "backend", IsActive: false}, u)
+
+	// Members of another team are not moved, and nothing of the batch is written.
+	err = d.Users.AddTeamMembers(ctx, "frontend", []domain.User{
+		{UserID: "u3", Username: "Carol", IsActive: true},
+		{UserID: "u1", Username: "Alice", IsActive: true},
+	})
+	assert.True(t, errors.Is(err, teamdomain.ErrMemberOfOtherTeam))
+
+	u, err = d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", u.TeamName)
+	_, err = d.Users.GetByID(ctx, "u3")
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+
+	// Deleted users are not restored this way.
+	require.NoError(t, d.Users.Delete(ctx, "u2"))
+	err = d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	})
+	assert.True(t, errors.Is(err, domain.ErrUserExists))
+}
+
+func testListByTeam(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+	d.SeedTeam(t, "frontend")
+
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u2", Username: "Bob", IsActive: true},
+		{UserID: "u1", Username: "Alice", IsActive: false},
+	}))
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "frontend", []domain.User{
+		{UserID: "u3", Username: "Carol", IsActive: true},
+	}))
+
+	users, err := d.Users.ListByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: false},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}, users)
+
+	users, err = d.Users.ListByTeam(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func testUpdateActive(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+	}))
+
+	require.NoError(t, d.Users.UpdateActive(ctx, "u1", false))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.False(t, u.IsActive)
+
+	err = d.Users.UpdateActive(ctx, "missing", true)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+}
+
+func testDeactivateByTeam(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+	d.SeedTeam(t, "frontend")
+
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}))
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "frontend", []domain.User{
+		{UserID: "u3", Username: "Carol", IsActive: true},
+	}))
+
+	require.NoError(t, d.Users.DeactivateByTeam(ctx, "backend"))
+
+	users, err := d.Users.ListByTeam(ctx, "backend")
+	require.NoError(t, err)
+	for _, u := range users {
+		assert.False(t, u.IsActive, u.UserID)
+	}
+
+	u, err := d.Users.GetByID(ctx, "u3")
+	require.NoError(t, err)
+	assert.True(t, u.IsActive)
+}
+
+func testDeactivateUsers(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1", "u2", "u3")
+
+	require.NoError(t, d.Users.DeactivateUsers(ctx, []string{"u1", "u3", "missing"}))
+	require.NoError(t, d.Users.DeactivateUsers(ctx, nil))
+
+	for id, active := range map[string]bool{"u1": false, "u2": true, "u3": false} {
+		u, err := d.Users.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, active, u.IsActive, id)
+	}
+}
+
+func seedUsers(t *testing.T, d Deps, ids ...string) {
+	t.Helper()
+
+	d.SeedTeam(t, "backend")
+
+	members := make([]domain.User, 0, len(ids))
+	for _, id := range ids {
+		members = append(members, domain.User{UserID: id, Username: id, IsActive: true})
+	}
+	require.NoError(t, d.Users.AddTeamMembers(context.Background(), "backend", members))
+}
+
+func testAbsenceCRUD(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1")
+
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	later := &domain.Absence{UserID: "u1", StartsAt: start.AddDate(0, 1, 0), EndsAt: start.AddDate(0, 1, 7), Reason: "conference"}
+	first := &domain.Absence{UserID: "u1", StartsAt: start, EndsAt: start.AddDate(0, 0, 14), Reason: "vacation"}
+	require.NoError(t, d.Users.CreateAbsence(ctx, later))
+	require.NoError(t, d.Users.CreateAbsence(ctx, first))
+	assert.NotZero(t, first.AbsenceID)
+	assert.NotEqual(t, first.AbsenceID, later.AbsenceID)
+
+	list, err := d.Users.ListAbsences(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, *first, list[0])
+	assert.Equal(t, *later, list[1])
+
+	first.EndsAt = start.AddDate(0, 0, 7)
+	first.Reason = "shorter vacation"
+	require.NoError(t, d.Users.UpdateAbsence(ctx, *first))
+
+	got, err := d.Users.GetAbsence(ctx, first.AbsenceID)
+	require.NoError(t, err)
+	assert.Equal(t, *first, *got)
+
+	require.NoError(t, d.Users.DeleteAbsence(ctx, first.AbsenceID))
+
+	list, err = d.Users.ListAbsences(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Absence{*later}, list)
+}
+
+func testAbsenceNotFound(t *testing.T, d Deps) {
+	ctx := context.Background()
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	err := d.Users.CreateAbsence(ctx, &domain.Absence{UserID: "missing", StartsAt: start, EndsAt: start.Add(time.Hour)})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+
+	_, err = d.Users.GetAbsence(ctx, 42)
+	assert.True(t, errors.Is(err, domain.ErrAbsenceNotFound))
+
+	err = d.Users.UpdateAbsence(ctx, domain.Absence{AbsenceID: 42, StartsAt: start, EndsAt: start.Add(time.Hour)})
+	assert.True(t, errors.Is(err, domain.ErrAbsenceNotFound))
+
+	err = d.Users.DeleteAbsence(ctx, 42)
+	assert.True(t, errors.Is(err, domain.ErrAbsenceNotFound))
+}
+
+func testListAbsent(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1", "u2", "u3")
+
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	require.NoError(t, d.Users.CreateAbsence(ctx, &domain.Absence{UserID: "u1", StartsAt: start, EndsAt: end}))
+	require.NoError(t, d.Users.CreateAbsence(ctx, &domain.Absence{UserID: "u2", StartsAt: end, EndsAt: end.AddDate(0, 0, 1)}))
+
+	absent, err := d.Users.ListAbsent(ctx, []string{"u1", "u2", "u3"}, start)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, absent)
+
+	absent, err = d.Users.ListAbsent(ctx, []string{"u1", "u2", "u3"}, end)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, absent)
+
+	absent, err = d.Users.ListAbsent(ctx, []string{"u2", "u3"}, start)
+	require.NoError(t, err)
+	assert.Empty(t, absent)
+}
+
+func testReviewLimits(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1", "u2")
+
+	limits, err := d.Users.ListReviewLimits(ctx, []string{"u1", "u2"})
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	require.NoError(t, d.Users.SetMaxOpenReviews(ctx, "u1", 3))
+
+	limits, err = d.Users.ListReviewLimits(ctx, []string{"u1", "u2", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReviewLimits{"u1": 3}, limits)
+
+	require.NoError(t, d.Users.SetMaxOpenReviews(ctx, "u1", 0))
+
+	limits, err = d.Users.ListReviewLimits(ctx, []string{"u1"})
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	err = d.Users.SetMaxOpenReviews(ctx, "missing", 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+}
+
+func testCreate(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+
+	require.NoError(t, d.Users.Create(ctx, domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, u)
+
+	err = d.Users.Create(ctx, domain.User{UserID: "u1", Username: "Alice B.", TeamName: "backend", IsActive: true})
+	assert.True(t, errors.Is(err, domain.ErrUserExists))
+
+	err = d.Users.Create(ctx, domain.User{UserID: "u2", Username: "Bob", TeamName: "unknown", IsActive: true})
+	assert.True(t, errors.Is(err, teamdomain.ErrTeamNotFound))
+}
+
+func testList(t *testing.T, d Deps) {
+	ctx := context.Background()
+	d.SeedTeam(t, "backend")
+	d.SeedTeam(t, "frontend")
+
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "backend", []domain.User{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: false},
+		{UserID: "u3", Username: "Carol", IsActive: true},
+	}))
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "frontend", []domain.User{
+		{UserID: "u4", Username: "Dave", IsActive: true},
+	}))
+
+	ids := func(users []domain.User) []string {
+		var res []string
+		for _, u := range users {
+			res = append(res, u.UserID)
+		}
+		return res
+	}
+
+	users, err := d.Users.List(ctx, domain.ListFilter{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u2"}, ids(users))
+
+	users, err = d.Users.List(ctx, domain.ListFilter{After: "u2", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u4"}, ids(users))
+
+	active := true
+	users, err = d.Users.List(ctx, domain.ListFilter{TeamName: "backend", IsActive: &active, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1", "u3"}, ids(users))
+}
+
+func testUpdateUsername(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1")
+
+	require.NoError(t, d.Users.UpdateUsername(ctx, "u1", "Alice B."))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice B.", u.Username)
+
+	err = d.Users.UpdateUsername(ctx, "missing", "Nobody")
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+}
+
+func testDelete(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1", "u2")
+
+	require.NoError(t, d.Users.Delete(ctx, "u1"))
+
+	_, err := d.Users.GetByID(ctx, "u1")
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+
+	users, err := d.Users.List(ctx, domain.ListFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "u2", users[0].UserID)
+
+	assert.True(t, errors.Is(d.Users.Delete(ctx, "u1"), domain.ErrUserNotFound))
+
+	// Creating the same id again brings the user back.
+	require.NoError(t, d.Users.Create(ctx, domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.True(t, u.IsActive)
+}
+
+func testMoveToTeam(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1")
+	d.SeedTeam(t, "frontend")
+
+	m := &domain.TeamMove{UserID: "u1", FromTeam: "backend", ToTeam: "frontend"}
+	require.NoError(t, d.Users.MoveToTeam(ctx, m))
+	assert.NotZero(t, m.MoveID)
+	assert.False(t, m.MovedAt.IsZero())
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", u.TeamName)
+
+	err = d.Users.MoveToTeam(ctx, &domain.TeamMove{UserID: "u1", FromTeam: "frontend", ToTeam: "unknown"})
+	assert.True(t, errors.Is(err, teamdomain.ErrTeamNotFound))
+
+	err = d.Users.MoveToTeam(ctx, &domain.TeamMove{UserID: "missing", ToTeam: "frontend"})
+	assert.True(t, errors.Is(err, domain.ErrUserNotFound))
+
+	moves, err := d.Users.ListTeamMoves(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, moves, 1)
+	assert.Equal(t, "backend", moves[0].FromTeam)
+	assert.Equal(t, "frontend", moves[0].ToTeam)
+}
+
+func testRemoveFromTeam(t *testing.T, d Deps) {
+	ctx := context.Background()
+	seedUsers(t, d, "u1", "u2")
+	d.SeedTeam(t, "frontend")
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "frontend", []domain.User{
+		{UserID: "u3", Username: "u3", IsActive: true},
+	}))
+
+	// Only members of the given team are affected.
+	require.NoError(t, d.Users.RemoveFromTeam(ctx, "backend", []string{"u1", "u3"}))
+
+	u, err := d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "u1", Username: "u1", TeamName: "", IsActive: true}, u)
+
+	u, err = d.Users.GetByID(ctx, "u3")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", u.TeamName)
+
+	members, err := d.Users.ListByTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, "u2", members[0].UserID)
+
+	users, err := d.Users.List(ctx, domain.ListFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+
+	// A user without a team can join any team.
+	require.NoError(t, d.Users.AddTeamMembers(ctx, "frontend", []domain.User{
+		{UserID: "u1", Username: "u1", IsActive: true},
+	}))
+
+	u, err = d.Users.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", u.TeamName)
+}
@@ -1,8 +1,23 @@
 package domain
 
+import "fmt"
+
 type User struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
 }
+
+// Validate checks the fields required to create a user.
+func (u User) Validate() error {
+	switch {
+	case u.UserID == "":
+		return fmt.Errorf("%w: user_id is required", ErrInvalidUser)
+	case u.Username == "":
+		return fmt.Errorf("%w: username is required", ErrInvalidUser)
+	case u.TeamName == "":
+		return fmt.Errorf("%w: team_name is required", ErrInvalidUser)
+	}
+	return nil
+}
@@ -0,0 +1,492 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	"github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+)
+
+type Repository struct {
+	store *memstore.Store
+}
+
+func NewUserRepository(store *memstore.Store) *Repository {
+	return &Repository{store: store}
+}
+
+func (r *Repository) AddTeamMembers(ctx context.Context, teamName string, members []domain.User) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Teams[teamName]; !ok {
+			return fmt.Errorf("%w: team %s does not exist", domain.ErrInternalDatabase, teamName)
+		}
+
+		for _, m := range members {
+			row, ok := t.Users[m.UserID]
+			switch {
+			case !ok:
+			case row.DeletedAt != nil:
+				return fmt.Errorf("%w: %s was deleted", domain.ErrUserExists, m.UserID)
+			case row.TeamName != "" && row.TeamName != teamName:
+				return fmt.Errorf("%w: %s", teamdomain.ErrMemberOfOtherTeam, m.UserID)
+			}
+		}
+
+		now := time.Now().UTC()
+		for _, m := range members {
+			row, ok := t.Users[m.UserID]
+			if !ok {
+				row = memstore.User{
+					UserID:    m.UserID,
+					TeamName:  teamName,
+					CreatedAt: now,
+				}
+			}
+
+			row.Username = m.Username
+			row.TeamName = teamName
+			row.IsActive = m.IsActive
+			row.UpdatedAt = now
+			t.Users[m.UserID] = row
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) Create(ctx context.Context, u domain.User) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Teams[u.TeamName]; !ok {
+			return fmt.Errorf("%w: %s", teamdomain.ErrTeamNotFound, u.TeamName)
+		}
+
+		now := time.Now().UTC()
+		row, ok := t.Users[u.UserID]
+		switch {
+		case !ok:
+			row = memstore.User{
+				UserID:    u.UserID,
+				CreatedAt: now,
+			}
+		case row.DeletedAt == nil:
+			return fmt.Errorf("%w: %s", domain.ErrUserExists, u.UserID)
+		}
+
+		row.Username = u.Username
+		row.TeamName = u.TeamName
+		row.IsActive = u.IsActive
+		row.UpdatedAt = now
+		row.DeletedAt = nil
+		t.Users[u.UserID] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	var (
+		row   memstore.User
+		found bool
+	)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		row, found = liveUser(t, id)
+	})
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+	}
+
+	return toDomain(row), nil
+}
+
+func (r *Repository) ListByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	var users []*domain.User
+	if teamName == "" {
+		return users, nil
+	}
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, row := range t.Users {
+			if row.TeamName == teamName && row.DeletedAt == nil {
+				users = append(users, toDomain(row))
+			}
+		}
+	})
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserID < users[j].UserID
+	})
+
+	return users, nil
+}
+
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]domain.User, error) {
+	var users []domain.User
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, row := range t.Users {
+			if row.DeletedAt != nil {
+				continue
+			}
+			if filter.TeamName != "" && row.TeamName != filter.TeamName {
+				continue
+			}
+			if filter.IsActive != nil && row.IsActive != *filter.IsActive {
+				continue
+			}
+			if filter.After != "" && row.UserID <= filter.After {
+				continue
+			}
+			users = append(users, *toDomain(row))
+		}
+	})
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserID < users[j].UserID
+	})
+
+	if filter.Limit > 0 && len(users) > filter.Limit {
+		users = users[:filter.Limit]
+	}
+
+	return users, nil
+}
+
+func (r *Repository) UpdateUsername(ctx context.Context, id string, username string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := liveUser(t, id)
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+		}
+
+		row.Username = username
+		row.UpdatedAt = time.Now().UTC()
+		t.Users[id] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := liveUser(t, id)
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+		}
+
+		now := time.Now().UTC()
+		row.IsActive = false
+		row.UpdatedAt = now
+		row.DeletedAt = &now
+		t.Users[id] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) MoveToTeam(ctx context.Context, m *domain.TeamMove) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := liveUser(t, m.UserID)
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, m.UserID)
+		}
+		if _, ok := t.Teams[m.ToTeam]; !ok {
+			return fmt.Errorf("%w: %s", teamdomain.ErrTeamNotFound, m.ToTeam)
+		}
+
+		now := time.Now().UTC()
+		row.TeamName = m.ToTeam
+		row.UpdatedAt = now
+		t.Users[m.UserID] = row
+
+		t.LastTeamMoveID++
+		m.MoveID = t.LastTeamMoveID
+		m.MovedAt = now
+		t.TeamMoves[m.MoveID] = memstore.TeamMove{
+			MoveID:   m.MoveID,
+			UserID:   m.UserID,
+			FromTeam: m.FromTeam,
+			ToTeam:   m.ToTeam,
+			MovedAt:  m.MovedAt,
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) RemoveFromTeam(ctx context.Context, teamName string, ids []string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		now := time.Now().UTC()
+		for _, id := range ids {
+			row, ok := liveUser(t, id)
+			if !ok || row.TeamName != teamName {
+				continue
+			}
+			row.TeamName = ""
+			row.UpdatedAt = now
+			t.Users[id] = row
+		}
+		return nil
+	})
+}
+
+func (r *Repository) ListTeamMoves(ctx context.Context, userID string) ([]domain.TeamMove, error) {
+	var res []domain.TeamMove
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, row := range t.TeamMoves {
+			if row.UserID == userID {
+				res = append(res, domain.TeamMove{
+					MoveID:   row.MoveID,
+					UserID:   row.UserID,
+					FromTeam: row.FromTeam,
+					ToTeam:   row.ToTeam,
+					MovedAt:  row.MovedAt,
+				})
+			}
+		}
+	})
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].MovedAt.Equal(res[j].MovedAt) {
+			return res[i].MovedAt.Before(res[j].MovedAt)
+		}
+		return res[i].MoveID < res[j].MoveID
+	})
+
+	return res, nil
+}
+
+func (r *Repository) UpdateActive(ctx context.Context, id string, active bool) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := liveUser(t, id)
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+		}
+
+		row.IsActive = active
+		row.UpdatedAt = time.Now().UTC()
+		t.Users[id] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) DeactivateByTeam(ctx context.Context, teamName string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		now := time.Now().UTC()
+		for id, row := range t.Users {
+			if row.TeamName != teamName || row.DeletedAt != nil {
+				continue
+			}
+			row.IsActive = false
+			row.UpdatedAt = now
+			t.Users[id] = row
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) DeactivateUsers(ctx context.Context, ids []string) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		now := time.Now().UTC()
+		for _, id := range ids {
+			row, ok := liveUser(t, id)
+			if !ok {
+				continue
+			}
+			row.IsActive = false
+			row.UpdatedAt = now
+			t.Users[id] = row
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) CreateAbsence(ctx context.Context, a *domain.Absence) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Users[a.UserID]; !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, a.UserID)
+		}
+
+		t.LastAbsenceID++
+		a.AbsenceID = t.LastAbsenceID
+		t.Absences[a.AbsenceID] = memstore.Absence{
+			AbsenceID: a.AbsenceID,
+			UserID:    a.UserID,
+			StartsAt:  a.StartsAt,
+			EndsAt:    a.EndsAt,
+			Reason:    a.Reason,
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) GetAbsence(ctx context.Context, id int64) (*domain.Absence, error) {
+	var (
+		row   memstore.Absence
+		found bool
+	)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		row, found = t.Absences[id]
+	})
+
+	if !found {
+		return nil, fmt.Errorf("%w: %d", domain.ErrAbsenceNotFound, id)
+	}
+
+	a := absenceToDomain(row)
+	return &a, nil
+}
+
+func (r *Repository) ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	var res []domain.Absence
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, row := range t.Absences {
+			if row.UserID == userID {
+				res = append(res, absenceToDomain(row))
+			}
+		}
+	})
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].StartsAt.Equal(res[j].StartsAt) {
+			return res[i].StartsAt.Before(res[j].StartsAt)
+		}
+		return res[i].AbsenceID < res[j].AbsenceID
+	})
+
+	return res, nil
+}
+
+func (r *Repository) UpdateAbsence(ctx context.Context, a domain.Absence) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := t.Absences[a.AbsenceID]
+		if !ok {
+			return fmt.Errorf("%w: %d", domain.ErrAbsenceNotFound, a.AbsenceID)
+		}
+
+		row.StartsAt = a.StartsAt
+		row.EndsAt = a.EndsAt
+		row.Reason = a.Reason
+		t.Absences[a.AbsenceID] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) DeleteAbsence(ctx context.Context, id int64) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		if _, ok := t.Absences[id]; !ok {
+			return fmt.Errorf("%w: %d", domain.ErrAbsenceNotFound, id)
+		}
+
+		delete(t.Absences, id)
+
+		return nil
+	})
+}
+
+func (r *Repository) ListAbsent(ctx context.Context, userIDs []string, at time.Time) ([]string, error) {
+	wanted := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = struct{}{}
+	}
+
+	absent := make(map[string]struct{})
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, row := range t.Absences {
+			if _, ok := wanted[row.UserID]; !ok {
+				continue
+			}
+			if absenceToDomain(row).Covers(at) {
+				absent[row.UserID] = struct{}{}
+			}
+		}
+	})
+
+	var res []string
+	for id := range absent {
+		res = append(res, id)
+	}
+	sort.Strings(res)
+
+	return res, nil
+}
+
+func (r *Repository) SetMaxOpenReviews(ctx context.Context, id string, limit int) error {
+	return r.store.Write(ctx, func(t *memstore.Tables) error {
+		row, ok := liveUser(t, id)
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+		}
+
+		row.MaxOpenReviews = limit
+		row.UpdatedAt = time.Now().UTC()
+		t.Users[id] = row
+
+		return nil
+	})
+}
+
+func (r *Repository) ListReviewLimits(ctx context.Context, userIDs []string) (domain.ReviewLimits, error) {
+	limits := make(domain.ReviewLimits)
+
+	r.store.Read(ctx, func(t *memstore.Tables) {
+		for _, id := range userIDs {
+			row, ok := t.Users[id]
+			if !ok {
+				continue
+			}
+
+			limit := row.MaxOpenReviews
+			if limit == 0 {
+				limit = t.Teams[row.TeamName].MaxOpenReviewsPerUser
+			}
+			if limit > 0 {
+				limits[id] = limit
+			}
+		}
+	})
+
+	return limits, nil
+}
+
+// liveUser looks a user up by id, treating soft-deleted rows as missing.
+func liveUser(t *memstore.Tables, id string) (memstore.User, bool) {
+	row, ok := t.Users[id]
+	if !ok || row.DeletedAt != nil {
+		return memstore.User{}, false
+	}
+	return row, true
+}
+
+func absenceToDomain(row memstore.Absence) domain.Absence {
+	return domain.Absence{
+		AbsenceID: row.AbsenceID,
+		UserID:    row.UserID,
+		StartsAt:  row.StartsAt,
+		EndsAt:    row.EndsAt,
+		Reason:    row.Reason,
+	}
+}
+
+func toDomain(row memstore.User) *domain.User {
+	return &domain.User{
+		UserID:   row.UserID,
+		Username: row.Username,
+		TeamName: row.TeamName,
+		IsActive: row.IsActive,
+	}
+}
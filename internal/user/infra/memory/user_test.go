@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teammemory "github.com/dunooo0ooo/avito-test-task/internal/team/infra/memory"
+	"github.com/dunooo0ooo/avito-test-task/internal/user/domain/repotest"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Deps {
+		store := memstore.New()
+		teams := teammemory.NewTeamRepository(store)
+
+		return repotest.Deps{
+			Users: NewUserRepository(store),
+			SeedTeam: func(t *testing.T, teamName string) {
+				require.NoError(t, teams.Create(context.Background(), &teamdomain.Team{TeamName: teamName}))
+			},
+		}
+	})
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
 	"github.com/dunooo0ooo/avito-test-task/internal/user/domain"
+	"github.com/dunooo0ooo/avito-test-task/pkg/txmanager"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
+	"time"
 )
 
 type Repository struct {
@@ -17,8 +22,12 @@ func NewUserRepository(pool *pgxpool.Pool) *Repository {
 	return &Repository{pool: pool}
 }
 
+func (r *Repository) conn(ctx context.Context) txmanager.Querier {
+	return txmanager.Conn(ctx, r.pool)
+}
+
 func (r *Repository) AddTeamMembers(ctx context.Context, teamName string, members []domain.User) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -26,6 +35,8 @@ func (r *Repository) AddTeamMembers(ctx context.Context, teamName string, member
 		_ = tx.Rollback(ctx)
 	}(tx, ctx)
 
+	// Only members of the same team and users without a team are updated; a user
+	// of another team or a deleted one is left as is and reported.
 	const query = `
 		INSERT INTO users (user_id, username, team_name, is_active)
 		VALUES (@id, @username, @team_name, @is_active)
@@ -35,6 +46,14 @@ func (r *Repository) AddTeamMembers(ctx context.Context, teamName string, member
 			team_name  = EXCLUDED.team_name,
 			is_active  = EXCLUDED.is_active,
 			updated_at = NOW()
+		WHERE (users.team_name IS NULL OR users.team_name = EXCLUDED.team_name)
+		  AND users.deleted_at IS NULL
+	`
+
+	const conflictQuery = `
+		SELECT deleted_at IS NOT NULL
+		FROM users
+		WHERE user_id = @id
 	`
 
 	for _, m := range members {
@@ -45,9 +64,22 @@ func (r *Repository) AddTeamMembers(ctx context.Context, teamName string, member
 			"is_active": m.IsActive,
 		}
 
-		if _, err := r.pool.Exec(ctx, query, args); err != nil {
+		tag, err := tx.Exec(ctx, query, args)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		if tag.RowsAffected() > 0 {
+			continue
+		}
+
+		var deleted bool
+		if err := tx.QueryRow(ctx, conflictQuery, args).Scan(&deleted); err != nil {
 			return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 		}
+		if deleted {
+			return fmt.Errorf("%w: %s was deleted", domain.ErrUserExists, m.UserID)
+		}
+		return fmt.Errorf("%w: %s", teamdomain.ErrMemberOfOtherTeam, m.UserID)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -57,17 +89,55 @@ func (r *Repository) AddTeamMembers(ctx context.Context, teamName string, member
 	return nil
 }
 
+func (r *Repository) Create(ctx context.Context, u domain.User) error {
+	const query = `
+		INSERT INTO users (user_id, username, team_name, is_active)
+		VALUES (@id, @username, @team_name, @is_active)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			username   = EXCLUDED.username,
+			team_name  = EXCLUDED.team_name,
+			is_active  = EXCLUDED.is_active,
+			deleted_at = NULL,
+			updated_at = NOW()
+		WHERE users.deleted_at IS NOT NULL
+	`
+
+	args := pgx.NamedArgs{
+		"id":        u.UserID,
+		"username":  u.Username,
+		"team_name": u.TeamName,
+		"is_active": u.IsActive,
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: %w", teamdomain.ErrTeamNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrUserExists, u.UserID)
+	}
+
+	return nil
+}
+
 func (r *Repository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	const query = `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, COALESCE(team_name, ''), is_active
 		FROM users
 		WHERE user_id = @id
+		  AND deleted_at IS NULL
 	`
 
 	args := pgx.NamedArgs{"id": id}
 
 	var user domain.User
-	err := r.pool.QueryRow(ctx, query, args).Scan(
+	err := r.conn(ctx).QueryRow(ctx, query, args).Scan(
 		&user.UserID,
 		&user.Username,
 		&user.TeamName,
@@ -88,11 +158,13 @@ func (r *Repository) ListByTeam(ctx context.Context, teamName string) ([]*domain
 		SELECT user_id, username, team_name, is_active
 		FROM users
 		WHERE team_name = @teamName
+		  AND deleted_at IS NULL
+		ORDER BY user_id
 	`
 
 	args := pgx.NamedArgs{"teamName": teamName}
 
-	rows, err := r.pool.Query(ctx, query, args)
+	rows, err := r.conn(ctx).Query(ctx, query, args)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -120,8 +192,200 @@ func (r *Repository) ListByTeam(ctx context.Context, teamName string) ([]*domain
 	return users, nil
 }
 
+func (r *Repository) List(ctx context.Context, filter domain.ListFilter) ([]domain.User, error) {
+	where := []string{"deleted_at IS NULL"}
+	args := pgx.NamedArgs{"limit": filter.Limit}
+
+	if filter.TeamName != "" {
+		where = append(where, "team_name = @team_name")
+		args["team_name"] = filter.TeamName
+	}
+	if filter.IsActive != nil {
+		where = append(where, "is_active = @is_active")
+		args["is_active"] = *filter.IsActive
+	}
+	if filter.After != "" {
+		where = append(where, "user_id > @after")
+		args["after"] = filter.After
+	}
+
+	query := `
+		SELECT user_id, username, COALESCE(team_name, ''), is_active
+		FROM users
+		WHERE ` + strings.Join(where, "\n\t\t  AND ") + `
+		ORDER BY user_id
+		LIMIT @limit
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(
+			&user.UserID,
+			&user.Username,
+			&user.TeamName,
+			&user.IsActive,
+		); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return users, nil
+}
+
+func (r *Repository) UpdateUsername(ctx context.Context, id string, username string) error {
+	const query = `
+		UPDATE users
+		SET username = @username,
+		    updated_at = NOW()
+		WHERE user_id = @id
+		  AND deleted_at IS NULL
+	`
+
+	args := pgx.NamedArgs{
+		"id":       id,
+		"username": username,
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", domain.ErrUserNotFound, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	const query = `
+		UPDATE users
+		SET is_active = FALSE,
+		    deleted_at = NOW(),
+		    updated_at = NOW()
+		WHERE user_id = @id
+		  AND deleted_at IS NULL
+	`
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", domain.ErrUserNotFound, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository) MoveToTeam(ctx context.Context, m *domain.TeamMove) error {
+	const updateQuery = `
+		UPDATE users
+		SET team_name = @to_team,
+		    updated_at = NOW()
+		WHERE user_id = @id
+		  AND deleted_at IS NULL
+	`
+
+	cmd, err := r.conn(ctx).Exec(ctx, updateQuery, pgx.NamedArgs{"id": m.UserID, "to_team": m.ToTeam})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: %w", teamdomain.ErrTeamNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", domain.ErrUserNotFound, pgx.ErrNoRows)
+	}
+
+	const insertQuery = `
+		INSERT INTO user_team_moves (user_id, from_team, to_team)
+		VALUES (@id, @from_team, @to_team)
+		RETURNING move_id, moved_at
+	`
+
+	args := pgx.NamedArgs{
+		"id":        m.UserID,
+		"from_team": m.FromTeam,
+		"to_team":   m.ToTeam,
+	}
+
+	if err := r.conn(ctx).QueryRow(ctx, insertQuery, args).Scan(&m.MoveID, &m.MovedAt); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveFromTeam(ctx context.Context, teamName string, ids []string) error {
+	const query = `
+		UPDATE users
+		SET team_name = NULL,
+		    updated_at = NOW()
+		WHERE team_name = @team_name
+		  AND user_id = ANY(@ids)
+		  AND deleted_at IS NULL
+	`
+
+	args := pgx.NamedArgs{"team_name": teamName, "ids": ids}
+
+	if _, err := r.conn(ctx).Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListTeamMoves(ctx context.Context, userID string) ([]domain.TeamMove, error) {
+	const query = `
+		SELECT move_id, user_id, from_team, to_team, moved_at
+		FROM user_team_moves
+		WHERE user_id = @user_id
+		ORDER BY moved_at, move_id
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, pgx.NamedArgs{"user_id": userID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	var res []domain.TeamMove
+
+	for rows.Next() {
+		var m domain.TeamMove
+		if err := rows.Scan(&m.MoveID, &m.UserID, &m.FromTeam, &m.ToTeam, &m.MovedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		res = append(res, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return res, nil
+}
+
 func (r *Repository) UpdateActive(ctx context.Context, id string, active bool) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -134,6 +398,7 @@ func (r *Repository) UpdateActive(ctx context.Context, id string, active bool) e
 		SET is_active = @active,
 		    updated_at = NOW()
 		WHERE user_id = @id
+		  AND deleted_at IS NULL
 	`
 
 	args := pgx.NamedArgs{
@@ -141,7 +406,7 @@ func (r *Repository) UpdateActive(ctx context.Context, id string, active bool) e
 		"active": active,
 	}
 
-	cmd, err := r.pool.Exec(ctx, query, args)
+	cmd, err := tx.Exec(ctx, query, args)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -158,7 +423,7 @@ func (r *Repository) UpdateActive(ctx context.Context, id string, active bool) e
 }
 
 func (r *Repository) DeactivateByTeam(ctx context.Context, teamName string) error {
-	tx, err := r.pool.Begin(ctx)
+	tx, err := r.conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -171,11 +436,12 @@ func (r *Repository) DeactivateByTeam(ctx context.Context, teamName string) erro
 		SET is_active = FALSE,
 		    updated_at = NOW()
 		WHERE team_name = @teamName
+		  AND deleted_at IS NULL
 	`
 
 	args := pgx.NamedArgs{"teamName": teamName}
 
-	_, err = r.pool.Exec(ctx, query, args)
+	_, err = tx.Exec(ctx, query, args)
 	if err != nil {
 		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
 	}
@@ -186,3 +452,259 @@ func (r *Repository) DeactivateByTeam(ctx context.Context, teamName string) erro
 
 	return nil
 }
+
+func (r *Repository) DeactivateUsers(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	const query = `
+		UPDATE users
+		SET is_active = FALSE,
+		    updated_at = NOW()
+		WHERE user_id = ANY(@ids)
+		  AND deleted_at IS NULL
+	`
+
+	if _, err := r.conn(ctx).Exec(ctx, query, pgx.NamedArgs{"ids": ids}); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateAbsence(ctx context.Context, a *domain.Absence) error {
+	const query = `
+		INSERT INTO user_absences (user_id, starts_at, ends_at, reason)
+		VALUES (@user_id, @starts_at, @ends_at, @reason)
+		RETURNING absence_id
+	`
+
+	args := pgx.NamedArgs{
+		"user_id":   a.UserID,
+		"starts_at": a.StartsAt,
+		"ends_at":   a.EndsAt,
+		"reason":    a.Reason,
+	}
+
+	if err := r.conn(ctx).QueryRow(ctx, query, args).Scan(&a.AbsenceID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: %w", domain.ErrUserNotFound, err)
+		}
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetAbsence(ctx context.Context, id int64) (*domain.Absence, error) {
+	const query = `
+		SELECT absence_id, user_id, starts_at, ends_at, reason
+		FROM user_absences
+		WHERE absence_id = @id
+	`
+
+	var a domain.Absence
+	err := r.conn(ctx).QueryRow(ctx, query, pgx.NamedArgs{"id": id}).Scan(
+		&a.AbsenceID,
+		&a.UserID,
+		&a.StartsAt,
+		&a.EndsAt,
+		&a.Reason,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %w", domain.ErrAbsenceNotFound, err)
+		}
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return &a, nil
+}
+
+func (r *Repository) ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	const query = `
+		SELECT absence_id, user_id, starts_at, ends_at, reason
+		FROM user_absences
+		WHERE user_id = @user_id
+		ORDER BY starts_at, absence_id
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, pgx.NamedArgs{"user_id": userID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	var res []domain.Absence
+
+	for rows.Next() {
+		var a domain.Absence
+		if err := rows.Scan(&a.AbsenceID, &a.UserID, &a.StartsAt, &a.EndsAt, &a.Reason); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		res = append(res, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return res, nil
+}
+
+func (r *Repository) UpdateAbsence(ctx context.Context, a domain.Absence) error {
+	const query = `
+		UPDATE user_absences
+		SET starts_at = @starts_at,
+		    ends_at   = @ends_at,
+		    reason    = @reason
+		WHERE absence_id = @id
+	`
+
+	args := pgx.NamedArgs{
+		"id":        a.AbsenceID,
+		"starts_at": a.StartsAt,
+		"ends_at":   a.EndsAt,
+		"reason":    a.Reason,
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", domain.ErrAbsenceNotFound, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteAbsence(ctx context.Context, id int64) error {
+	const query = `
+		DELETE FROM user_absences
+		WHERE absence_id = @id
+	`
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", domain.ErrAbsenceNotFound, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListAbsent(ctx context.Context, userIDs []string, at time.Time) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	const query = `
+		SELECT DISTINCT user_id
+		FROM user_absences
+		WHERE user_id = ANY(@user_ids)
+		  AND starts_at <= @at
+		  AND ends_at > @at
+		ORDER BY user_id
+	`
+
+	args := pgx.NamedArgs{
+		"user_ids": userIDs,
+		"at":       at,
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	var res []string
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		res = append(res, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return res, nil
+}
+
+func (r *Repository) SetMaxOpenReviews(ctx context.Context, id string, limit int) error {
+	const query = `
+		UPDATE users
+		SET max_open_reviews = @limit,
+		    updated_at = NOW()
+		WHERE user_id = @id
+		  AND deleted_at IS NULL
+	`
+
+	args := pgx.NamedArgs{
+		"id":    id,
+		"limit": limit,
+	}
+
+	cmd, err := r.conn(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %w", domain.ErrUserNotFound, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListReviewLimits(ctx context.Context, userIDs []string) (domain.ReviewLimits, error) {
+	limits := make(domain.ReviewLimits)
+	if len(userIDs) == 0 {
+		return limits, nil
+	}
+
+	const query = `
+		SELECT u.user_id,
+		       CASE WHEN u.max_open_reviews > 0 THEN u.max_open_reviews
+		            ELSE t.max_open_reviews_per_user
+		       END AS review_limit
+		FROM users u
+		LEFT JOIN teams t ON t.team_name = u.team_name
+		WHERE u.user_id = ANY(@user_ids)
+		  AND (u.max_open_reviews > 0 OR t.max_open_reviews_per_user > 0)
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, query, pgx.NamedArgs{"user_ids": userIDs})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			limit int
+		)
+		if err := rows.Scan(&id, &limit); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+		}
+		limits[id] = limit
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInternalDatabase, err)
+	}
+
+	return limits, nil
+}
@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	teamdomain "github.com/dunooo0ooo/avito-test-task/internal/team/domain"
+	teampg "github.com/dunooo0ooo/avito-test-task/internal/team/infra/postgres"
+	"github.com/dunooo0ooo/avito-test-task/internal/user/domain/repotest"
+	"github.com/dunooo0ooo/avito-test-task/pkg/pgtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	pool := pgtest.Pool(t)
+	teams := teampg.NewTeamRepository(pool)
+
+	repotest.Run(t, func(t *testing.T) repotest.Deps {
+		pgtest.Reset(t, pool)
+
+		return repotest.Deps{
+			Users: NewUserRepository(pool),
+			SeedTeam: func(t *testing.T, teamName string) {
+				require.NoError(t, teams.Create(context.Background(), &teamdomain.Team{TeamName: teamName}))
+			},
+		}
+	})
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/dunooo0ooo/avito-test-task/internal/user/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,34 @@ func (mr *MockUserRepositoryMockRecorder) AddTeamMembers(ctx, teamName, members
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMembers", reflect.TypeOf((*MockUserRepository)(nil).AddTeamMembers), ctx, teamName, members)
 }
 
+// Create mocks base method.
+func (m *MockUserRepository) Create(ctx context.Context, u domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, u)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserRepositoryMockRecorder) Create(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepository)(nil).Create), ctx, u)
+}
+
+// CreateAbsence mocks base method.
+func (m *MockUserRepository) CreateAbsence(ctx context.Context, a *domain.Absence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAbsence", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAbsence indicates an expected call of CreateAbsence.
+func (mr *MockUserRepositoryMockRecorder) CreateAbsence(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAbsence", reflect.TypeOf((*MockUserRepository)(nil).CreateAbsence), ctx, a)
+}
+
 // DeactivateByTeam mocks base method.
 func (m *MockUserRepository) DeactivateByTeam(ctx context.Context, teamName string) error {
 	m.ctrl.T.Helper()
@@ -63,6 +92,63 @@ func (mr *MockUserRepositoryMockRecorder) DeactivateByTeam(ctx, teamName interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateByTeam", reflect.TypeOf((*MockUserRepository)(nil).DeactivateByTeam), ctx, teamName)
 }
 
+// DeactivateUsers mocks base method.
+func (m *MockUserRepository) DeactivateUsers(ctx context.Context, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUsers", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUsers indicates an expected call of DeactivateUsers.
+func (mr *MockUserRepositoryMockRecorder) DeactivateUsers(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUsers", reflect.TypeOf((*MockUserRepository)(nil).DeactivateUsers), ctx, ids)
+}
+
+// Delete mocks base method.
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteAbsence mocks base method.
+func (m *MockUserRepository) DeleteAbsence(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAbsence", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAbsence indicates an expected call of DeleteAbsence.
+func (mr *MockUserRepositoryMockRecorder) DeleteAbsence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAbsence", reflect.TypeOf((*MockUserRepository)(nil).DeleteAbsence), ctx, id)
+}
+
+// GetAbsence mocks base method.
+func (m *MockUserRepository) GetAbsence(ctx context.Context, id int64) (*domain.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbsence", ctx, id)
+	ret0, _ := ret[0].(*domain.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbsence indicates an expected call of GetAbsence.
+func (mr *MockUserRepositoryMockRecorder) GetAbsence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbsence", reflect.TypeOf((*MockUserRepository)(nil).GetAbsence), ctx, id)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +164,51 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockUserRepository) List(ctx context.Context, filter domain.ListFilter) ([]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, filter)
+}
+
+// ListAbsences mocks base method.
+func (m *MockUserRepository) ListAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbsences", ctx, userID)
+	ret0, _ := ret[0].([]domain.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbsences indicates an expected call of ListAbsences.
+func (mr *MockUserRepositoryMockRecorder) ListAbsences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsences", reflect.TypeOf((*MockUserRepository)(nil).ListAbsences), ctx, userID)
+}
+
+// ListAbsent mocks base method.
+func (m *MockUserRepository) ListAbsent(ctx context.Context, userIDs []string, at time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbsent", ctx, userIDs, at)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbsent indicates an expected call of ListAbsent.
+func (mr *MockUserRepositoryMockRecorder) ListAbsent(ctx, userIDs, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsent", reflect.TypeOf((*MockUserRepository)(nil).ListAbsent), ctx, userIDs, at)
+}
+
 // ListByTeam mocks base method.
 func (m *MockUserRepository) ListByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +224,92 @@ func (mr *MockUserRepositoryMockRecorder) ListByTeam(ctx, teamName interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTeam", reflect.TypeOf((*MockUserRepository)(nil).ListByTeam), ctx, teamName)
 }
 
+// ListReviewLimits mocks base method.
+func (m *MockUserRepository) ListReviewLimits(ctx context.Context, userIDs []string) (domain.ReviewLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewLimits", ctx, userIDs)
+	ret0, _ := ret[0].(domain.ReviewLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewLimits indicates an expected call of ListReviewLimits.
+func (mr *MockUserRepositoryMockRecorder) ListReviewLimits(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewLimits", reflect.TypeOf((*MockUserRepository)(nil).ListReviewLimits), ctx, userIDs)
+}
+
+// ListTeamMoves mocks base method.
+func (m *MockUserRepository) ListTeamMoves(ctx context.Context, userID string) ([]domain.TeamMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamMoves", ctx, userID)
+	ret0, _ := ret[0].([]domain.TeamMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamMoves indicates an expected call of ListTeamMoves.
+func (mr *MockUserRepositoryMockRecorder) ListTeamMoves(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamMoves", reflect.TypeOf((*MockUserRepository)(nil).ListTeamMoves), ctx, userID)
+}
+
+// MoveToTeam mocks base method.
+func (m_2 *MockUserRepository) MoveToTeam(ctx context.Context, m *domain.TeamMove) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "MoveToTeam", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToTeam indicates an expected call of MoveToTeam.
+func (mr *MockUserRepositoryMockRecorder) MoveToTeam(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToTeam", reflect.TypeOf((*MockUserRepository)(nil).MoveToTeam), ctx, m)
+}
+
+// RemoveFromTeam mocks base method.
+func (m *MockUserRepository) RemoveFromTeam(ctx context.Context, teamName string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromTeam", ctx, teamName, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromTeam indicates an expected call of RemoveFromTeam.
+func (mr *MockUserRepositoryMockRecorder) RemoveFromTeam(ctx, teamName, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromTeam", reflect.TypeOf((*MockUserRepository)(nil).RemoveFromTeam), ctx, teamName, ids)
+}
+
+// SetMaxOpenReviews mocks base method.
+func (m *MockUserRepository) SetMaxOpenReviews(ctx context.Context, id string, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxOpenReviews", ctx, id, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaxOpenReviews indicates an expected call of SetMaxOpenReviews.
+func (mr *MockUserRepositoryMockRecorder) SetMaxOpenReviews(ctx, id, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxOpenReviews", reflect.TypeOf((*MockUserRepository)(nil).SetMaxOpenReviews), ctx, id, limit)
+}
+
+// UpdateAbsence mocks base method.
+func (m *MockUserRepository) UpdateAbsence(ctx context.Context, a domain.Absence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAbsence", ctx, a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAbsence indicates an expected call of UpdateAbsence.
+func (mr *MockUserRepositoryMockRecorder) UpdateAbsence(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAbsence", reflect.TypeOf((*MockUserRepository)(nil).UpdateAbsence), ctx, a)
+}
+
 // UpdateActive mocks base method.
 func (m *MockUserRepository) UpdateActive(ctx context.Context, id string, active bool) error {
 	m.ctrl.T.Helper()
@@ -106,3 +323,17 @@ func (mr *MockUserRepositoryMockRecorder) UpdateActive(ctx, id, active interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActive", reflect.TypeOf((*MockUserRepository)(nil).UpdateActive), ctx, id, active)
 }
+
+// UpdateUsername mocks base method.
+func (m *MockUserRepository) UpdateUsername(ctx context.Context, id, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", ctx, id, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockUserRepositoryMockRecorder) UpdateUsername(ctx, id, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockUserRepository)(nil).UpdateUsername), ctx, id, username)
+}
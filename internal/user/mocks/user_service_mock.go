@@ -36,18 +36,94 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// AddAbsence mocks base method.
+func (m *MockUserService) AddAbsence(ctx context.Context, a domain0.Absence, reassign bool) (*domain0.Absence, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAbsence", ctx, a, reassign)
+	ret0, _ := ret[0].(*domain0.Absence)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddAbsence indicates an expected call of AddAbsence.
+func (mr *MockUserServiceMockRecorder) AddAbsence(ctx, a, reassign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAbsence", reflect.TypeOf((*MockUserService)(nil).AddAbsence), ctx, a, reassign)
+}
+
+// CreateUser mocks base method.
+func (m *MockUserService) CreateUser(ctx context.Context, u domain0.User) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, u)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserServiceMockRecorder) CreateUser(ctx, u interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserService)(nil).CreateUser), ctx, u)
+}
+
 // DeactivateTeamUsersAndReassign mocks base method.
-func (m *MockUserService) DeactivateTeamUsersAndReassign(ctx context.Context, teamName string, userIDs []string) error {
+func (m *MockUserService) DeactivateTeamUsersAndReassign(ctx context.Context, teamName string, userIDs []string, dryRun bool) (*domain0.DeactivationReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeactivateTeamUsersAndReassign", ctx, teamName, userIDs)
+	ret := m.ctrl.Call(m, "DeactivateTeamUsersAndReassign", ctx, teamName, userIDs, dryRun)
+	ret0, _ := ret[0].(*domain0.DeactivationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTeamUsersAndReassign indicates an expected call of DeactivateTeamUsersAndReassign.
+func (mr *MockUserServiceMockRecorder) DeactivateTeamUsersAndReassign(ctx, teamName, userIDs, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeamUsersAndReassign", reflect.TypeOf((*MockUserService)(nil).DeactivateTeamUsersAndReassign), ctx, teamName, userIDs, dryRun)
+}
+
+// DeleteAbsence mocks base method.
+func (m *MockUserService) DeleteAbsence(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAbsence", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeactivateTeamUsersAndReassign indicates an expected call of DeactivateTeamUsersAndReassign.
-func (mr *MockUserServiceMockRecorder) DeactivateTeamUsersAndReassign(ctx, teamName, userIDs interface{}) *gomock.Call {
+// DeleteAbsence indicates an expected call of DeleteAbsence.
+func (mr *MockUserServiceMockRecorder) DeleteAbsence(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAbsence", reflect.TypeOf((*MockUserService)(nil).DeleteAbsence), ctx, id)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserService) DeleteUser(ctx context.Context, userID string) ([]domain.ReviewerChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].([]domain.ReviewerChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeamUsersAndReassign", reflect.TypeOf((*MockUserService)(nil).DeactivateTeamUsersAndReassign), ctx, teamName, userIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockUserService) GetUser(ctx context.Context, userID string) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserServiceMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserService)(nil).GetUser), ctx, userID)
 }
 
 // GetUserReviews mocks base method.
@@ -65,6 +141,81 @@ func (mr *MockUserServiceMockRecorder) GetUserReviews(ctx, userID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviews", reflect.TypeOf((*MockUserService)(nil).GetUserReviews), ctx, userID)
 }
 
+// ListAbsences mocks base method.
+func (m *MockUserService) ListAbsences(ctx context.Context, userID string) ([]domain0.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbsences", ctx, userID)
+	ret0, _ := ret[0].([]domain0.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbsences indicates an expected call of ListAbsences.
+func (mr *MockUserServiceMockRecorder) ListAbsences(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbsences", reflect.TypeOf((*MockUserService)(nil).ListAbsences), ctx, userID)
+}
+
+// ListTeamMoves mocks base method.
+func (m *MockUserService) ListTeamMoves(ctx context.Context, userID string) ([]domain0.TeamMove, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTeamMoves", ctx, userID)
+	ret0, _ := ret[0].([]domain0.TeamMove)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTeamMoves indicates an expected call of ListTeamMoves.
+func (mr *MockUserServiceMockRecorder) ListTeamMoves(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTeamMoves", reflect.TypeOf((*MockUserService)(nil).ListTeamMoves), ctx, userID)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserService) ListUsers(ctx context.Context, filter domain0.ListFilter) (*domain0.UserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter)
+	ret0, _ := ret[0].(*domain0.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserServiceMockRecorder) ListUsers(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx, filter)
+}
+
+// MoveTeam mocks base method.
+func (m *MockUserService) MoveTeam(ctx context.Context, userID, toTeam string, reassign bool) (*domain0.TeamMoveResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTeam", ctx, userID, toTeam, reassign)
+	ret0, _ := ret[0].(*domain0.TeamMoveResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTeam indicates an expected call of MoveTeam.
+func (mr *MockUserServiceMockRecorder) MoveTeam(ctx, userID, toTeam, reassign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTeam", reflect.TypeOf((*MockUserService)(nil).MoveTeam), ctx, userID, toTeam, reassign)
+}
+
+// RemoveTeamMembers mocks base method.
+func (m *MockUserService) RemoveTeamMembers(ctx context.Context, teamName string, userIDs []string) (*domain0.RemovalReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamMembers", ctx, teamName, userIDs)
+	ret0, _ := ret[0].(*domain0.RemovalReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTeamMembers indicates an expected call of RemoveTeamMembers.
+func (mr *MockUserServiceMockRecorder) RemoveTeamMembers(ctx, teamName, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMembers", reflect.TypeOf((*MockUserService)(nil).RemoveTeamMembers), ctx, teamName, userIDs)
+}
+
 // SetIsActive mocks base method.
 func (m *MockUserService) SetIsActive(ctx context.Context, userID string, active bool) (*domain0.User, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +230,47 @@ func (mr *MockUserServiceMockRecorder) SetIsActive(ctx, userID, active interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsActive", reflect.TypeOf((*MockUserService)(nil).SetIsActive), ctx, userID, active)
 }
+
+// SetMaxOpenReviews mocks base method.
+func (m *MockUserService) SetMaxOpenReviews(ctx context.Context, userID string, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxOpenReviews", ctx, userID, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMaxOpenReviews indicates an expected call of SetMaxOpenReviews.
+func (mr *MockUserServiceMockRecorder) SetMaxOpenReviews(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxOpenReviews", reflect.TypeOf((*MockUserService)(nil).SetMaxOpenReviews), ctx, userID, limit)
+}
+
+// UpdateAbsence mocks base method.
+func (m *MockUserService) UpdateAbsence(ctx context.Context, a domain0.Absence) (*domain0.Absence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAbsence", ctx, a)
+	ret0, _ := ret[0].(*domain0.Absence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAbsence indicates an expected call of UpdateAbsence.
+func (mr *MockUserServiceMockRecorder) UpdateAbsence(ctx, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAbsence", reflect.TypeOf((*MockUserService)(nil).UpdateAbsence), ctx, a)
+}
+
+// UpdateUsername mocks base method.
+func (m *MockUserService) UpdateUsername(ctx context.Context, userID, username string) (*domain0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsername", ctx, userID, username)
+	ret0, _ := ret[0].(*domain0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUsername indicates an expected call of UpdateUsername.
+func (mr *MockUserServiceMockRecorder) UpdateUsername(ctx, userID, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsername", reflect.TypeOf((*MockUserService)(nil).UpdateUsername), ctx, userID, username)
+}
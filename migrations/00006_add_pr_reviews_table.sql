@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS pr_reviews
+(
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests (pull_request_id),
+    reviewer_id     VARCHAR(255) NOT NULL REFERENCES users (user_id),
+    decision        VARCHAR(50)  NOT NULL CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    submitted_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pr_reviews;
+-- +goose StatementEnd
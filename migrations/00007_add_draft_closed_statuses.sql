@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check
+        CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE pull_requests
+SET status = 'OPEN'
+WHERE status IN ('DRAFT', 'CLOSED');
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check
+        CHECK (status IN ('OPEN', 'MERGED'));
+-- +goose StatementEnd
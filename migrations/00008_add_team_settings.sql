@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_reviewers  INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_reviewers  INTEGER NOT NULL DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS self_team_only BOOLEAN NOT NULL DEFAULT true;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_reviewers_check
+        CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS teams_reviewers_check;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS self_team_only,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS team_code_owners
+(
+    team_name VARCHAR(255) NOT NULL REFERENCES teams (team_name),
+    position  INTEGER      NOT NULL,
+    pattern   TEXT         NOT NULL,
+    owners    TEXT[]       NOT NULL,
+    PRIMARY KEY (team_name, position)
+);
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS changed_files TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS changed_files;
+
+DROP TABLE IF EXISTS team_code_owners;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_absences
+(
+    absence_id BIGSERIAL PRIMARY KEY,
+    user_id    VARCHAR(255) NOT NULL REFERENCES users (user_id),
+    starts_at  TIMESTAMP    NOT NULL,
+    ends_at    TIMESTAMP    NOT NULL,
+    reason     TEXT         NOT NULL DEFAULT '',
+    created_at TIMESTAMP    NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_absences_period_check CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_period
+    ON user_absences (user_id, starts_at, ends_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_absences;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS max_open_reviews_per_user INT NOT NULL DEFAULT 0
+        CHECK (max_open_reviews_per_user >= 0);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INT NOT NULL DEFAULT 0
+        CHECK (max_open_reviews >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS max_open_reviews;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS max_open_reviews_per_user;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS user_team_moves
+(
+    move_id   BIGSERIAL PRIMARY KEY,
+    user_id   VARCHAR(255) NOT NULL REFERENCES users (user_id),
+    from_team VARCHAR(255) NOT NULL,
+    to_team   VARCHAR(255) NOT NULL,
+    moved_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_team_moves_user_moved_at
+    ON user_team_moves (user_id, moved_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_team_moves;
+-- +goose StatementEnd
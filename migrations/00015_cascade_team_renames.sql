@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+    ALTER COLUMN team_name DROP NOT NULL;
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ADD CONSTRAINT users_team_name_fkey
+        FOREIGN KEY (team_name) REFERENCES teams (team_name)
+            ON UPDATE CASCADE ON DELETE SET NULL;
+
+ALTER TABLE team_code_owners
+    DROP CONSTRAINT IF EXISTS team_code_owners_team_name_fkey,
+    ADD CONSTRAINT team_code_owners_team_name_fkey
+        FOREIGN KEY (team_name) REFERENCES teams (team_name)
+            ON UPDATE CASCADE ON DELETE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_code_owners
+    DROP CONSTRAINT IF EXISTS team_code_owners_team_name_fkey,
+    ADD CONSTRAINT team_code_owners_team_name_fkey
+        FOREIGN KEY (team_name) REFERENCES teams (team_name);
+
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_team_name_fkey,
+    ADD CONSTRAINT users_team_name_fkey
+        FOREIGN KEY (team_name) REFERENCES teams (team_name);
+
+ALTER TABLE users
+    ALTER COLUMN team_name SET NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at_id
+    ON pull_requests (created_at, pull_request_id);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status_created_at_id
+    ON pull_requests (status, created_at, pull_request_id);
+
+DROP INDEX IF EXISTS idx_pull_requests_status_created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status_created_at
+    ON pull_requests (status, created_at);
+
+DROP INDEX IF EXISTS idx_pull_requests_status_created_at_id;
+DROP INDEX IF EXISTS idx_pull_requests_created_at_id;
+-- +goose StatementEnd
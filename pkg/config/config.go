@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type HTTPConfig struct {
@@ -25,10 +26,28 @@ type LoggerConfig struct {
 	Level string
 }
 
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+type StorageConfig struct {
+	Driver string
+}
+
+type ReviewersConfig struct {
+	Strategy              string
+	TeamStrategies        map[string]string
+	RequiredApprovals     int
+	TeamRequiredApprovals map[string]int
+}
+
 type Config struct {
-	HTTP     HTTPConfig
-	Postgres PostgresConfig
-	Logger   LoggerConfig
+	HTTP      HTTPConfig
+	Storage   StorageConfig
+	Postgres  PostgresConfig
+	Logger    LoggerConfig
+	Reviewers ReviewersConfig
 }
 
 func getenv(key, def string) string {
@@ -58,11 +77,40 @@ func getenvInt32(key string, def int32) int32 {
 	return def
 }
 
+// getenvMap parses values like "backend:round_robin,infra:least_loaded".
+func getenvMap(key string) map[string]string {
+	res := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || k == "" {
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// getenvIntMap parses values like "backend:2,infra:1", skipping malformed pairs.
+func getenvIntMap(key string) map[string]int {
+	res := make(map[string]int)
+	for k, v := range getenvMap(key) {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			continue
+		}
+		res[k] = n
+	}
+	return res
+}
+
 func Load() Config {
 	return Config{
 		HTTP: HTTPConfig{
 			Addr: getenv("HTTP_ADDR", ":8080"),
 		},
+		Storage: StorageConfig{
+			Driver: getenv("STORAGE", StoragePostgres),
+		},
 		Postgres: PostgresConfig{
 			Host:          getenv("POSTGRES_HOST", "localhost"),
 			Port:          getenvInt("POSTGRES_PORT", 5432),
@@ -77,6 +125,12 @@ func Load() Config {
 		Logger: LoggerConfig{
 			Level: getenv("LOG_LEVEL", "info"),
 		},
+		Reviewers: ReviewersConfig{
+			Strategy:              getenv("REVIEWER_STRATEGY", "random"),
+			TeamStrategies:        getenvMap("REVIEWER_TEAM_STRATEGIES"),
+			RequiredApprovals:     getenvInt("REVIEW_REQUIRED_APPROVALS", 0),
+			TeamRequiredApprovals: getenvIntMap("REVIEW_TEAM_REQUIRED_APPROVALS"),
+		},
 	}
 }
 
@@ -0,0 +1,211 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Team struct {
+	TeamName              string
+	MinReviewers          int
+	MaxReviewers          int
+	SelfTeamOnly          bool
+	FallbackTeams         []string
+	MaxOpenReviewsPerUser int
+	CreatedAt             time.Time
+}
+
+type User struct {
+	UserID         string
+	Username       string
+	TeamName       string
+	IsActive       bool
+	MaxOpenReviews int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+type PullRequest struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Status          string
+	ChangedFiles    []string
+	CreatedAt       time.Time
+	MergedAt        *time.Time
+}
+
+type Absence struct {
+	AbsenceID int64
+	UserID    string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+}
+
+type TeamMove struct {
+	MoveID   int64
+	UserID   string
+	FromTeam string
+	ToTeam   string
+	MovedAt  time.Time
+}
+
+type CodeOwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+type Review struct {
+	ReviewerID  string
+	Decision    string
+	SubmittedAt time.Time
+}
+
+// Tables mirrors the postgres schema; Reviewers maps pull_request_id to reviewer IDs,
+// Reviews maps pull_request_id to decisions keyed by reviewer ID and CodeOwners maps
+// team_name to its ordered rules. LastAbsenceID and LastTeamMoveID play the role of the
+// absence_id and move_id sequences.
+type Tables struct {
+	Teams          map[string]Team
+	Users          map[string]User
+	PullRequests   map[string]PullRequest
+	Reviewers      map[string][]string
+	Reviews        map[string]map[string]Review
+	CodeOwners     map[string][]CodeOwnerRule
+	Absences       map[int64]Absence
+	LastAbsenceID  int64
+	TeamMoves      map[int64]TeamMove
+	LastTeamMoveID int64
+}
+
+func newTables() *Tables {
+	return &Tables{
+		Teams:        make(map[string]Team),
+		Users:        make(map[string]User),
+		PullRequests: make(map[string]PullRequest),
+		Reviewers:    make(map[string][]string),
+		Reviews:      make(map[string]map[string]Review),
+		CodeOwners:   make(map[string][]CodeOwnerRule),
+		Absences:     make(map[int64]Absence),
+		TeamMoves:    make(map[int64]TeamMove),
+	}
+}
+
+func (t *Tables) clone() *Tables {
+	c := newTables()
+	for k, v := range t.Teams {
+		v.FallbackTeams = append([]string(nil), v.FallbackTeams...)
+		c.Teams[k] = v
+	}
+	for k, v := range t.Users {
+		c.Users[k] = v
+	}
+	for k, v := range t.PullRequests {
+		v.ChangedFiles = append([]string(nil), v.ChangedFiles...)
+		c.PullRequests[k] = v
+	}
+	for k, v := range t.Reviewers {
+		c.Reviewers[k] = append([]string(nil), v...)
+	}
+	for k, v := range t.Reviews {
+		c.Reviews[k] = make(map[string]Review, len(v))
+		for rid, rv := range v {
+			c.Reviews[k][rid] = rv
+		}
+	}
+	for k, v := range t.CodeOwners {
+		rules := make([]CodeOwnerRule, len(v))
+		for i, rule := range v {
+			rules[i] = CodeOwnerRule{
+				Pattern: rule.Pattern,
+				Owners:  append([]string(nil), rule.Owners...),
+			}
+		}
+		c.CodeOwners[k] = rules
+	}
+	for k, v := range t.Absences {
+		c.Absences[k] = v
+	}
+	c.LastAbsenceID = t.LastAbsenceID
+	for k, v := range t.TeamMoves {
+		c.TeamMoves[k] = v
+	}
+	c.LastTeamMoveID = t.LastTeamMoveID
+	return c
+}
+
+type txKey struct{}
+
+// Store is an in-memory database shared by the memory repositories.
+//
+// A transaction works on its own copy of the tables and publishes it on commit,
+// so others never see its uncommitted changes and a rollback simply drops the
+// copy. Writes outside a transaction wait for the running one to finish, the
+// way a Postgres row lock would make them wait, instead of being overwritten
+// by its commit.
+type Store struct {
+	mu     sync.RWMutex
+	txMu   sync.Mutex
+	tables *Tables
+}
+
+func New() *Store {
+	return &Store{tables: newTables()}
+}
+
+func txTables(ctx context.Context) (*Tables, bool) {
+	t, ok := ctx.Value(txKey{}).(*Tables)
+	return t, ok
+}
+
+func (s *Store) Read(ctx context.Context, fn func(t *Tables)) {
+	if t, ok := txTables(ctx); ok {
+		fn(t)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.tables)
+}
+
+func (s *Store) Write(ctx context.Context, fn func(t *Tables) error) error {
+	if t, ok := txTables(ctx); ok {
+		return fn(t)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.tables)
+}
+
+// WithinTx serializes transactions with each other and with writes made outside
+// them. Changes become visible when fn succeeds and are dropped when it fails.
+func (s *Store) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txTables(ctx); ok {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	work := s.tables.clone()
+	s.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, work)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.tables = work
+	s.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_WithinTx_RollbackOnError(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	require.NoError(t, s.Write(ctx, func(tb *Tables) error {
+		tb.Teams["backend"] = Team{TeamName: "backend"}
+		return nil
+	}))
+
+	expectedErr := errors.New("boom")
+	err := s.WithinTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, s.Write(ctx, func(tb *Tables) error {
+			tb.Teams["frontend"] = Team{TeamName: "frontend"}
+			delete(tb.Teams, "backend")
+			return nil
+		}))
+
+		return s.WithinTx(ctx, func(context.Context) error {
+			return expectedErr
+		})
+	})
+	require.ErrorIs(t, err, expectedErr)
+
+	s.Read(ctx, func(tb *Tables) {
+		assert.Contains(t, tb.Teams, "backend")
+		assert.NotContains(t, tb.Teams, "frontend")
+	})
+}
+
+func TestStore_WithinTx_Commit(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	err := s.WithinTx(ctx, func(ctx context.Context) error {
+		return s.Write(ctx, func(tb *Tables) error {
+			tb.Users["u1"] = User{UserID: "u1", TeamName: "backend"}
+			tb.Reviewers["pr-1"] = []string{"u1"}
+			return nil
+		})
+	})
+	require.NoError(t, err)
+
+	s.Read(ctx, func(tb *Tables) {
+		assert.Contains(t, tb.Users, "u1")
+		assert.Equal(t, []string{"u1"}, tb.Reviewers["pr-1"])
+	})
+}
+
+func TestStore_WithinTx_HidesUncommittedChanges(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	err := s.WithinTx(ctx, func(txCtx context.Context) error {
+		require.NoError(t, s.Write(txCtx, func(tb *Tables) error {
+			tb.Teams["backend"] = Team{TeamName: "backend"}
+			return nil
+		}))
+
+		s.Read(ctx, func(tb *Tables) {
+			assert.NotContains(t, tb.Teams, "backend")
+		})
+		s.Read(txCtx, func(tb *Tables) {
+			assert.Contains(t, tb.Teams, "backend")
+		})
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestStore_Write_WaitsForRolledBackTx(t *testing.T) {
+	s := New()
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	written := make(chan error)
+
+	go func() {
+		<-started
+		written <- s.Write(ctx, func(tb *Tables) error {
+			tb.Teams["frontend"] = Team{TeamName: "frontend"}
+			return nil
+		})
+	}()
+
+	expectedErr := errors.New("boom")
+	err := s.WithinTx(ctx, func(ctx context.Context) error {
+		close(started)
+
+		select {
+		case <-written:
+			t.Error("write outside the transaction did not wait for it")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		return expectedErr
+	})
+	require.ErrorIs(t, err, expectedErr)
+	require.NoError(t, <-written)
+
+	s.Read(ctx, func(tb *Tables) {
+		assert.Contains(t, tb.Teams, "frontend")
+	})
+}
@@ -0,0 +1,37 @@
+package pgtest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+const envDSN = "TEST_POSTGRES_DSN"
+
+// Pool connects to the migrated database from TEST_POSTGRES_DSN and skips the test when it is not set.
+func Pool(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv(envDSN)
+	if dsn == "" {
+		t.Skipf("%s is not set", envDSN)
+	}
+
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+// Reset removes all rows so every test starts from an empty database.
+func Reset(t testing.TB, pool *pgxpool.Pool) {
+	t.Helper()
+
+	_, err := pool.Exec(context.Background(),
+		`TRUNCATE user_absences, team_code_owners, pr_reviews, pr_reviewers, pull_requests, users, teams CASCADE`)
+	require.NoError(t, err)
+}
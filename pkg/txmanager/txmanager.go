@@ -0,0 +1,16 @@
+package txmanager
+
+import "context"
+
+// TxManager runs fn inside a single transaction. Repositories called with the
+// context passed to fn take part in that transaction.
+type TxManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Nop runs fn without any transaction.
+type Nop struct{}
+
+func (Nop) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -13,9 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-const baseURL = "http://localhost:8080"
-
 func Test_FullFlow_Team_PR_Reviews_Stats(t *testing.T) {
+	baseURL := newBaseURL(t)
 	client := &http.Client{Timeout: 5 * time.Second}
 
 	suffix := time.Now().UnixNano()
@@ -0,0 +1,36 @@
+package integration
+
+import (
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/dunooo0ooo/avito-test-task/internal/app"
+	"github.com/dunooo0ooo/avito-test-task/pkg/config"
+	"github.com/dunooo0ooo/avito-test-task/pkg/memstore"
+)
+
+// newBaseURL returns INTEGRATION_BASE_URL when it is set, otherwise it starts
+// the service on top of in-memory storage.
+func newBaseURL(t *testing.T) string {
+	t.Helper()
+
+	if url := os.Getenv("INTEGRATION_BASE_URL"); url != "" {
+		return url
+	}
+
+	mux, err := app.NewRouter(
+		app.NewMemoryStorage(memstore.New()),
+		config.ReviewersConfig{Strategy: "random"},
+		zap.NewNop(),
+	)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}